@@ -1,6 +1,9 @@
 package env
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 func in(needle string, haystack []string) bool {
 	for _, value := range haystack {
@@ -11,22 +14,85 @@ func in(needle string, haystack []string) bool {
 	return false
 }
 
-func boolValidator(value string) error {
-	booleanValues := []string{
-		"true", "false",
-		"yes", "no",
-		"1", "0",
+// BoolVocabulary is the set of words accepted as booleans. Words are matched
+// ignoring case.
+type BoolVocabulary int
+
+const (
+	// BoolStandard accepts true/false, yes/no and 1/0
+	BoolStandard BoolVocabulary = iota
+	// BoolExtended also accepts on/off, y/n, t/f and enabled/disabled
+	BoolExtended
+	// BoolStrict only accepts true/false
+	BoolStrict
+)
+
+// The words of each vocabulary, true and false words in pairs
+var boolWords = map[BoolVocabulary][]string{
+	BoolStandard: {"true", "false", "yes", "no", "1", "0"},
+	BoolExtended: {"true", "false", "yes", "no", "1", "0", "on", "off", "y", "n", "t", "f", "enabled", "disabled"},
+	BoolStrict:   {"true", "false"},
+}
+
+// The vocabularies by the name used in the `bool` tag option
+var boolVocabularies = map[string]BoolVocabulary{
+	"standard": BoolStandard,
+	"extended": BoolExtended,
+	"strict":   BoolStrict,
+}
+
+func (b BoolVocabulary) String() string {
+	for name, vocabulary := range boolVocabularies {
+		if vocabulary == b {
+			return name
+		}
+	}
+	return fmt.Sprintf("BoolVocabulary(%d)", int(b))
+}
+
+// Returns the accepted words, true and false words in pairs
+func (b BoolVocabulary) words() []string {
+	words, ok := boolWords[b]
+	if !ok {
+		panic(fmt.Sprintf("Unknown boolean vocabulary %d", int(b)))
 	}
-	if !in(value, booleanValues) {
+	return words
+}
+
+// Parses the value, ignoring case. The error lists the accepted words, which
+// is shown as the reason the value is invalid.
+func (b BoolVocabulary) parse(value string) (bool, error) {
+	for i, word := range b.words() {
+		if strings.EqualFold(value, word) {
+			return i%2 == 0, nil
+		}
+	}
+	return false, expectedError{"expected one of: " + strings.Join(b.words(), ", ")}
+}
+
+// Returns the vocabulary of the field, or the one of the source if the field
+// has none
+func (s *source) boolVocabularyFor(tag string) BoolVocabulary {
+	if vocabulary, ok := getBoolVocabulary(tag); ok {
+		return vocabulary
+	}
+	return s.bools
+}
+
+func boolValidator(value string) error {
+	_, err := BoolStandard.parse(value)
+	if err != nil {
 		return fmt.Errorf("invalid boolean value: %s", value)
 	}
 	return nil
 }
 
 func boolParser(value string) (bool, error) {
-	ok := boolValidator(value)
-	casted := in(value, []string{"true", "yes", "1"})
-	return casted, ok
+	if err := boolValidator(value); err != nil {
+		return false, err
+	}
+	casted, _ := BoolStandard.parse(value)
+	return casted, nil
 }
 
 type Bool bool
@@ -1,6 +1,10 @@
 package env
 
 import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -41,24 +45,24 @@ func TestBoolValidator(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:        "case sensitive - TRUE",
+			name:        "case insensitive - TRUE",
 			value:       "TRUE",
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:        "case sensitive - FALSE",
+			name:        "case insensitive - FALSE",
 			value:       "FALSE",
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:        "case sensitive - Yes",
+			name:        "case insensitive - Yes",
 			value:       "Yes",
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:        "case sensitive - No",
+			name:        "case insensitive - No",
 			value:       "No",
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name:        "invalid value",
@@ -225,3 +229,197 @@ func TestIn(t *testing.T) {
 		})
 	}
 }
+
+func TestBoolVocabularies(t *testing.T) {
+	tests := []struct {
+		vocabulary  BoolVocabulary
+		value       string
+		expected    bool
+		expectError bool
+	}{
+		{vocabulary: BoolStandard, value: "True", expected: true},
+		{vocabulary: BoolStandard, value: "NO", expected: false},
+		{vocabulary: BoolStandard, value: "on", expectError: true},
+		{vocabulary: BoolExtended, value: "on", expected: true},
+		{vocabulary: BoolExtended, value: "OFF", expected: false},
+		{vocabulary: BoolExtended, value: "Y", expected: true},
+		{vocabulary: BoolExtended, value: "n", expected: false},
+		{vocabulary: BoolExtended, value: "t", expected: true},
+		{vocabulary: BoolExtended, value: "F", expected: false},
+		{vocabulary: BoolExtended, value: "Enabled", expected: true},
+		{vocabulary: BoolExtended, value: "disabled", expected: false},
+		{vocabulary: BoolExtended, value: "maybe", expectError: true},
+		{vocabulary: BoolStrict, value: "TRUE", expected: true},
+		{vocabulary: BoolStrict, value: "false", expected: false},
+		{vocabulary: BoolStrict, value: "yes", expectError: true},
+		{vocabulary: BoolStrict, value: "1", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.vocabulary.String()+"/"+tt.value, func(t *testing.T) {
+			result, err := tt.vocabulary.parse(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got none", tt.value)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, result, err)
+			}
+		})
+	}
+}
+
+type TestConfigBoolVocabulary struct {
+	Debug   bool   `env:"required,name='BOOLDEBUG'"`
+	Feature bool   `env:"optional,bool='extended',name='BOOLFEATURE'"`
+	Strict  bool   `env:"optional,bool='strict',name='BOOLSTRICT'"`
+	Flags   []bool `env:"optional,separator=',',bool='extended',name='BOOLFLAGS'"`
+}
+
+func TestValidateBoolVocabulary(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		envVars  map[string]string
+		invalid  map[string]string
+		expected TestConfigBoolVocabulary
+	}{
+		{
+			name:     "field vocabularies",
+			envVars:  map[string]string{"BOOLDEBUG": "TRUE", "BOOLFEATURE": "on", "BOOLSTRICT": "False", "BOOLFLAGS": "enabled,N"},
+			expected: TestConfigBoolVocabulary{Debug: true, Feature: true, Flags: []bool{true, false}},
+		},
+		{
+			name:    "word outside the vocabulary",
+			envVars: map[string]string{"BOOLDEBUG": "on", "BOOLSTRICT": "yes"},
+			invalid: map[string]string{
+				"Debug (BOOLDEBUG)":   "expected one of: true, false, yes, no, 1, 0",
+				"Strict (BOOLSTRICT)": "expected one of: true, false",
+			},
+		},
+		{
+			name:    "slice element outside the vocabulary",
+			envVars: map[string]string{"BOOLDEBUG": "1", "BOOLFLAGS": "on,maybe"},
+			invalid: map[string]string{
+				"Flags (BOOLFLAGS)": "invalid slice: element 1 is not valid, expected one of: true, false, yes, no, 1, 0, on, off, y, n, t, f, enabled, disabled",
+			},
+		},
+		{
+			name:     "global vocabulary",
+			opts:     []Option{WithBoolVocabulary(BoolExtended)},
+			envVars:  map[string]string{"BOOLDEBUG": "Enabled", "BOOLSTRICT": "true"},
+			expected: TestConfigBoolVocabulary{Debug: true, Strict: true},
+		},
+		{
+			name:    "global strict vocabulary",
+			opts:    []Option{WithBoolVocabulary(BoolStrict)},
+			envVars: map[string]string{"BOOLDEBUG": "1", "BOOLFEATURE": "on"},
+			invalid: map[string]string{
+				"Debug (BOOLDEBUG)": "expected one of: true, false",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				t.Setenv(key, value)
+			}
+
+			config, err := Assert(TestConfigBoolVocabulary{}, tt.opts...)
+			if tt.invalid == nil {
+				if err != nil {
+					t.Fatalf("Assert failed: %v", err)
+				}
+				if !reflect.DeepEqual(config, tt.expected) {
+					t.Errorf("Expected %+v, got %+v", tt.expected, config)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected a validation error, got %v", err)
+			}
+			invalid := make(map[string]string)
+			for _, e := range validationErr.Invalid {
+				invalid[e.label()] = e.Reason
+			}
+			if !reflect.DeepEqual(invalid, tt.invalid) {
+				t.Errorf("Expected %v, got %v", tt.invalid, invalid)
+			}
+		})
+	}
+}
+
+func TestSchemaBoolPatterns(t *testing.T) {
+	schema := NewSchema(TestConfigBoolVocabulary{})
+
+	tests := []struct {
+		variable string
+		value    string
+		expected bool
+	}{
+		{"BOOLDEBUG", "TRUE", true},
+		{"BOOLDEBUG", "No", true},
+		{"BOOLDEBUG", "on", false},
+		{"BOOLFEATURE", "Enabled", true},
+		{"BOOLFEATURE", "maybe", false},
+		{"BOOLSTRICT", "False", true},
+		{"BOOLSTRICT", "1", false},
+		{"BOOLFLAGS", "on,OFF", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable+"="+tt.value, func(t *testing.T) {
+			pattern := schema.Properties[tt.variable].Pattern
+			if matched := regexp.MustCompile(pattern).MatchString(tt.value); matched != tt.expected {
+				t.Errorf("Expected %v for '%s' with pattern '%s'", tt.expected, tt.value, pattern)
+			}
+		})
+	}
+}
+
+func TestBoolOptionPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+	}{
+		{
+			name: "unknown vocabulary",
+			config: struct {
+				Debug bool `env:"required,bool='lenient',name='BOOLDEBUG'"`
+			}{},
+		},
+		{
+			name: "not a boolean",
+			config: struct {
+				Name string `env:"required,bool='extended',name='BOOLDEBUG'"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic")
+				}
+			}()
+			ValidateMap(tt.config, map[string]string{"BOOLDEBUG": "true"})
+		})
+	}
+}
+
+func TestRenderBoolVocabulary(t *testing.T) {
+	report := ValidateMap(TestConfigBoolVocabulary{}, map[string]string{"BOOLDEBUG": "1", "BOOLSTRICT": "yes"})
+	rendered := report.Render(false)
+	if !strings.Contains(rendered, "expected one of: true, false") {
+		t.Errorf("Expected the strict vocabulary in the report, got:\n%s", rendered)
+	}
+	if strings.Contains(rendered, "hint:") {
+		t.Errorf("Expected no hint contradicting the vocabulary, got:\n%s", rendered)
+	}
+}
@@ -0,0 +1,186 @@
+// Command envcheck validates environment variables against the JSON Schema
+// produced by env.JSONSchema, without starting the application. It is meant
+// to check a dotenv file or the env block of a manifest in CI.
+//
+// Usage:
+//
+//	envcheck -schema schema.json [-format auto|dotenv|json|list] [-allow-unknown] FILE
+//
+// The variables can be given as a dotenv file, as a JSON object of names to
+// values, as a JSON list of `{"name": ..., "value": ...}` objects like the env
+// block of a Kubernetes container, or as a list of `KEY=VALUE` lines taken
+// literally. With the `auto` format, the default, files ending in `.json` are
+// read as JSON and any other file as dotenv.
+//
+// It exits with status 1 if any variable is missing, invalid or unknown, and
+// with status 2 if the arguments or the files are not valid.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tooxie/env"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("envcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	schemaPath := flags.String("schema", "", "path to the JSON Schema produced by env.JSONSchema")
+	format := flags.String("format", "auto", "format of the variables: auto, dotenv, json or list")
+	allowUnknown := flags.Bool("allow-unknown", false, "do not fail on variables that are not in the schema")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *schemaPath == "" || flags.NArg() != 1 {
+		fmt.Fprintln(stderr, "usage: envcheck -schema schema.json [-format auto|dotenv|json|list] [-allow-unknown] FILE")
+		return 2
+	}
+
+	schema, err := readSchema(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "envcheck: %v\n", err)
+		return 2
+	}
+
+	variables, err := readVariables(flags.Arg(0), *format)
+	if err != nil {
+		fmt.Fprintf(stderr, "envcheck: %v\n", err)
+		return 2
+	}
+
+	report := schema.Check(variables)
+	if *allowUnknown {
+		report.Unknown = nil
+	}
+
+	if report.Valid() && len(report.Unknown) == 0 {
+		fmt.Fprintf(stdout, "%s: OK\n", flags.Arg(0))
+		return 0
+	}
+
+	fmt.Fprint(stdout, report.Render(false))
+	return 1
+}
+
+func readSchema(path string) (env.Schema, error) {
+	var schema env.Schema
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema, err
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return schema, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return schema, nil
+}
+
+func readVariables(path string, format string) (map[string]string, error) {
+	if format == "auto" {
+		format = "dotenv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = "json"
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var variables map[string]string
+	switch format {
+	case "dotenv":
+		variables, err = env.ParseDotenv(f)
+	case "json":
+		variables, err = parseJSON(f)
+	case "list":
+		variables, err = parseList(f)
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return variables, nil
+}
+
+// Parses either an object of names to values or a list of name and value
+// objects. Values that are not strings are converted to their JSON text.
+func parseJSON(r io.Reader) (map[string]string, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	variables := make(map[string]string)
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err == nil {
+		for name, value := range object {
+			variables[name] = jsonString(value)
+		}
+		return variables, nil
+	}
+
+	var list []struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, errors.New("expected an object or a list of name and value objects")
+	}
+	for _, item := range list {
+		variables[item.Name] = jsonString(item.Value)
+	}
+
+	return variables, nil
+}
+
+func jsonString(value json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		return s
+	}
+	if string(value) == "null" || len(value) == 0 {
+		return ""
+	}
+	return string(value)
+}
+
+// Parses `KEY=VALUE` lines, taking the values literally
+func parseList(r io.Reader) (map[string]string, error) {
+	variables := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		name, value, found := strings.Cut(line, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+		variables[name] = value
+	}
+
+	return variables, scanner.Err()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tooxie/env"
+)
+
+type testConfig struct {
+	Port     int    `env:"required"`
+	Env      string `env:"required,values='dev,prod',name='APP_ENV'"`
+	Password string `env:"optional,secret"`
+}
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write '%s': %v", path, err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	data, err := env.JSONSchema(testConfig{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	schema := writeFile(t, dir, "schema.json", string(data))
+
+	tests := []struct {
+		name         string
+		file         string
+		content      string
+		args         []string
+		expectedCode int
+		contains     []string
+		notContains  []string
+	}{
+		{
+			name:         "valid dotenv",
+			file:         ".env",
+			content:      "PORT=8080\nAPP_ENV=dev\n",
+			expectedCode: 0,
+			contains:     []string{"OK"},
+		},
+		{
+			name:         "missing, invalid and unknown",
+			file:         ".env",
+			content:      "PORT=http\nPASSWORD=hunter2\nAPP_ENVIRONMENT=dev\n",
+			expectedCode: 1,
+			contains:     []string{"Missing variables (1):\n  APP_ENV  string", "Invalid variables (1):\n  PORT  int: must match", "got \"http\"", "Unknown variables (1):\n  APP_ENVIRONMENT"},
+		},
+		{
+			name:         "unknown allowed",
+			file:         ".env",
+			content:      "PORT=8080\nAPP_ENV=dev\nHOME=/root\n",
+			args:         []string{"-allow-unknown"},
+			expectedCode: 0,
+		},
+		{
+			name:         "secret values are masked",
+			file:         "secrets.json",
+			content:      `{"PORT": 8080, "APP_ENV": "qa", "PASSWORD": "hunter2"}`,
+			expectedCode: 1,
+			contains:     []string{`APP_ENV  string: must be one of: dev, prod, got "qa"`},
+			notContains:  []string{"hunter2"},
+		},
+		{
+			name:         "kubernetes env block",
+			file:         "env.json",
+			content:      `[{"name": "PORT", "value": "8080"}, {"name": "APP_ENV", "value": "prod"}]`,
+			expectedCode: 0,
+		},
+		{
+			name:         "literal list",
+			file:         "env.list",
+			content:      "PORT=8080\nAPP_ENV=prod # comment\n",
+			args:         []string{"-format", "list"},
+			expectedCode: 1,
+			contains:     []string{`got "prod # comment"`},
+		},
+		{
+			name:         "invalid file",
+			file:         "broken.env",
+			content:      "PORT\n",
+			expectedCode: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, tt.file, tt.content)
+			args := append([]string{"-schema", schema}, tt.args...)
+			args = append(args, path)
+
+			var stdout, stderr strings.Builder
+			code := run(args, &stdout, &stderr)
+
+			if code != tt.expectedCode {
+				t.Errorf("Expected exit code %d, got %d\nstdout: %s\nstderr: %s", tt.expectedCode, code, stdout.String(), stderr.String())
+			}
+			for _, expected := range tt.contains {
+				if !strings.Contains(stdout.String(), expected) {
+					t.Errorf("Expected output to contain '%s', got:\n%s", expected, stdout.String())
+				}
+			}
+			for _, unexpected := range tt.notContains {
+				if strings.Contains(stdout.String(), unexpected) {
+					t.Errorf("Expected output not to contain '%s', got:\n%s", unexpected, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	var stdout, stderr strings.Builder
+	if code := run([]string{"file.env"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+	if !strings.Contains(stderr.String(), "usage:") {
+		t.Errorf("Expected usage, got: %s", stderr.String())
+	}
+}
@@ -0,0 +1,139 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Evaluates the `requiredif`, `requiredunless`, `oneof` and `exclusive` options.
+// These depend on the values of other fields, so they can only be checked once
+// every field of the struct has been read. The resolved map holds the value of
+// each field after applying defaults, and parserFor returns the parser of the
+// values of a field.
+func validateConditions(t reflect.Type, s scope, resolved map[string]string, environment envMapType, parserFor func(reflect.StructField) func(string) (any, error)) ([]FieldError, []FieldError) {
+	var missing []FieldError
+	var invalid []FieldError
+
+	// Groups are kept in the order in which they are first declared so that
+	// the errors are reported in a predictable order
+	var groups []string
+	oneOf := make(map[string][]reflect.StructField)
+	exclusive := make(map[string][]reflect.StructField)
+
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		tag := field.Tag.Get("env")
+		isSet := resolved[field.Name] != ""
+
+		if cond, ok := getRequiredIf(tag); ok {
+			if conditionHolds(t, s, cond, resolved, environment, parserFor) && !isSet {
+				missing = append(missing, s.fieldError(field, "", "required when "+cond.String()))
+			}
+		}
+
+		if cond, ok := getRequiredUnless(tag); ok {
+			if !conditionHolds(t, s, cond, resolved, environment, parserFor) && !isSet {
+				missing = append(missing, s.fieldError(field, "", "required unless "+cond.String()))
+			}
+		}
+
+		if group := getOneOf(tag); group != "" {
+			if _, ok := oneOf[group]; !ok {
+				groups = append(groups, group)
+			}
+			oneOf[group] = append(oneOf[group], field)
+		}
+
+		if group := getExclusive(tag); group != "" {
+			if _, ok := exclusive[group]; !ok {
+				groups = append(groups, group)
+			}
+			exclusive[group] = append(exclusive[group], field)
+		}
+	}
+
+	for _, group := range groups {
+		if fields, ok := oneOf[group]; ok {
+			set := fieldsWithValue(fields, resolved)
+			if len(set) == 0 {
+				reason := fmt.Sprintf("one of %s is required (group '%s')", fieldNames(fields), group)
+				for _, field := range fields {
+					missing = append(missing, s.fieldError(field, "", reason))
+				}
+			}
+			invalid = append(invalid, tooManyInGroup(s, group, set, resolved)...)
+		}
+
+		if fields, ok := exclusive[group]; ok {
+			invalid = append(invalid, tooManyInGroup(s, group, fieldsWithValue(fields, resolved), resolved)...)
+		}
+	}
+
+	return missing, invalid
+}
+
+// Checks whether the field referenced by the condition has the expected value.
+// Values are compared as strings first and then as parsed values, so that
+// `requiredif='TLSEnabled=true'` is also met when `TLSENABLED` is `yes` or `1`.
+// The expected value is parsed like the values of the field, so that enums and
+// custom boolean vocabularies are understood.
+func conditionHolds(t reflect.Type, s scope, cond condition, resolved map[string]string, environment envMapType, parserFor func(reflect.StructField) func(string) (any, error)) bool {
+	field, ok := t.FieldByName(cond.field)
+	if !ok {
+		panic(fmt.Sprintf("Unknown field '%s' in condition '%s'", cond.field, cond))
+	}
+
+	value := resolved[field.Name]
+	if !cond.hasValue {
+		return value != ""
+	}
+	if value == cond.value {
+		return true
+	}
+
+	envVar, ok := environment[s.key(field)]
+	if !ok || !envVar.Value.IsValid() || field.Type.Kind() == reflect.Slice {
+		return false
+	}
+
+	expected, err := parserFor(field)(cond.value)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(envVar.Value.Interface(), expected)
+}
+
+func fieldsWithValue(fields []reflect.StructField, resolved map[string]string) []reflect.StructField {
+	var set []reflect.StructField
+	for _, field := range fields {
+		if resolved[field.Name] != "" {
+			set = append(set, field)
+		}
+	}
+	return set
+}
+
+// Reports every field of the group that has a value when more than one does
+func tooManyInGroup(s scope, group string, set []reflect.StructField, resolved map[string]string) []FieldError {
+	if len(set) < 2 {
+		return nil
+	}
+
+	reason := fmt.Sprintf("only one of %s may be set (group '%s')", fieldNames(set), group)
+
+	invalid := make([]FieldError, len(set))
+	for i, field := range set {
+		invalid[i] = s.fieldError(field, resolved[field.Name], reason)
+	}
+	return invalid
+}
+
+func fieldNames(fields []reflect.StructField) string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,291 @@
+package env
+
+import (
+	"os"
+	"testing"
+)
+
+// Test structs for conditional requirements
+type TestConfigRequiredIf struct {
+	TLSEnabled bool   `env:"optional,default='false'"`
+	TLSCert    string `env:"requiredif='TLSEnabled=true'"`
+}
+
+type TestConfigRequiredUnless struct {
+	Mode     string `env:"optional,values='local,remote',default='remote'"`
+	Endpoint string `env:"requiredunless='Mode=local'"`
+}
+
+type TestConfigRequiredIfSet struct {
+	Username string `env:"optional"`
+	Password string `env:"requiredif='Username'"`
+}
+
+type TestConfigOneOf struct {
+	RedisURL       string   `env:"oneof='redis'"`
+	RedisSentinels []string `env:"oneof='redis',separator=','"`
+}
+
+type TestConfigExclusive struct {
+	Token    string `env:"optional,exclusive='auth'"`
+	Password string `env:"optional,exclusive='auth'"`
+}
+
+type TestConfigRequiredIfEnum struct {
+	Stage testEnvironment `env:"required,name='CONDSTAGE'"`
+	Level testLevel       `env:"optional,default='info',name='CONDLEVEL'"`
+	Key   string          `env:"requiredif='Stage=prod',name='CONDKEY'"`
+	Trace string          `env:"requiredif='Level=debug',name='CONDTRACE'"`
+}
+
+type TestConfigUnknownCondition struct {
+	TLSCert string `env:"requiredif='Unknown=true'"`
+}
+
+func TestConditionalValidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          interface{}
+		envVars         map[string]string
+		expectedMissing []string
+		expectedInvalid []string
+	}{
+		{
+			name:            "requiredif condition not met",
+			config:          TestConfigRequiredIf{},
+			envVars:         map[string]string{},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredif condition met and value missing",
+			config: TestConfigRequiredIf{},
+			envVars: map[string]string{
+				"TLSENABLED": "true",
+			},
+			expectedMissing: []string{"TLSCert (TLSCERT): required when TLSEnabled=true"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredif condition met by parsed value",
+			config: TestConfigRequiredIf{},
+			envVars: map[string]string{
+				"TLSENABLED": "yes",
+			},
+			expectedMissing: []string{"TLSCert (TLSCERT): required when TLSEnabled=true"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredif condition met and value set",
+			config: TestConfigRequiredIf{},
+			envVars: map[string]string{
+				"TLSENABLED": "true",
+				"TLSCERT":    "/etc/tls/cert.pem",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:            "requiredunless condition not met",
+			config:          TestConfigRequiredUnless{},
+			envVars:         map[string]string{},
+			expectedMissing: []string{"Endpoint (ENDPOINT): required unless Mode=local"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredunless condition met",
+			config: TestConfigRequiredUnless{},
+			envVars: map[string]string{
+				"MODE": "local",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:            "requiredif without value and field unset",
+			config:          TestConfigRequiredIfSet{},
+			envVars:         map[string]string{},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredif without value and field set",
+			config: TestConfigRequiredIfSet{},
+			envVars: map[string]string{
+				"USERNAME": "admin",
+			},
+			expectedMissing: []string{"Password (PASSWORD): required when Username is set"},
+			expectedInvalid: []string{},
+		},
+		{
+			name:    "oneof with no value",
+			config:  TestConfigOneOf{},
+			envVars: map[string]string{},
+			expectedMissing: []string{
+				"RedisURL (REDISURL): one of RedisURL, RedisSentinels is required (group 'redis')",
+				"RedisSentinels (REDISSENTINELS): one of RedisURL, RedisSentinels is required (group 'redis')",
+			},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "oneof with one value",
+			config: TestConfigOneOf{},
+			envVars: map[string]string{
+				"REDISSENTINELS": "10.0.0.1:26379,10.0.0.2:26379",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "oneof with both values",
+			config: TestConfigOneOf{},
+			envVars: map[string]string{
+				"REDISURL":       "redis://localhost:6379",
+				"REDISSENTINELS": "10.0.0.1:26379",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{"RedisURL (REDISURL)", "RedisSentinels (REDISSENTINELS)"},
+		},
+		{
+			name:            "exclusive with no value",
+			config:          TestConfigExclusive{},
+			envVars:         map[string]string{},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "exclusive with both values",
+			config: TestConfigExclusive{},
+			envVars: map[string]string{
+				"TOKEN":    "abc",
+				"PASSWORD": "secret",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{"Token (TOKEN)", "Password (PASSWORD)"},
+		},
+		{
+			name:   "requiredif on an enum not met",
+			config: TestConfigRequiredIfEnum{},
+			envVars: map[string]string{
+				"CONDSTAGE": "dev",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name:   "requiredif on an enum met",
+			config: TestConfigRequiredIfEnum{},
+			envVars: map[string]string{
+				"CONDSTAGE": "prod",
+				"CONDLEVEL": "debug",
+			},
+			expectedMissing: []string{
+				"Key (CONDKEY): required when Stage=prod",
+				"Trace (CONDTRACE): required when Level=debug",
+			},
+			expectedInvalid: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.envVars {
+					os.Unsetenv(key)
+				}
+			}()
+
+			missing, invalid := Validate(tt.config)
+
+			if len(missing) != len(tt.expectedMissing) {
+				t.Errorf("Expected %d missing fields, got %d: %v", len(tt.expectedMissing), len(missing), missing)
+			}
+			for i, expected := range tt.expectedMissing {
+				if i < len(missing) && missing[i] != expected {
+					t.Errorf("Expected missing field '%s', got '%s'", expected, missing[i])
+				}
+			}
+
+			if len(invalid) != len(tt.expectedInvalid) {
+				t.Errorf("Expected %d invalid fields, got %d: %v", len(tt.expectedInvalid), len(invalid), invalid)
+			}
+			for i, expected := range tt.expectedInvalid {
+				if i < len(invalid) && invalid[i].name != expected {
+					t.Errorf("Expected invalid field '%s', got '%s'", expected, invalid[i].name)
+				}
+				if i < len(invalid) && invalid[i].reason == "" {
+					t.Errorf("Expected a reason for invalid field '%s'", expected)
+				}
+			}
+		})
+	}
+}
+
+func TestConditionalValidationUnknownField(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for unknown field in condition")
+		}
+	}()
+
+	Validate(TestConfigUnknownCondition{})
+}
+
+func TestAssertConditionalZeroValue(t *testing.T) {
+	config, err := Assert(TestConfigRequiredIf{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.TLSCert != "" {
+		t.Errorf("Expected empty TLSCert, got '%s'", config.TLSCert)
+	}
+}
+
+func TestGetRequiredIf(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected condition
+		found    bool
+	}{
+		{
+			name:     "field and value",
+			tag:      "requiredif='TLSEnabled=true'",
+			expected: condition{field: "TLSEnabled", value: "true", hasValue: true},
+			found:    true,
+		},
+		{
+			name:     "field only",
+			tag:      "optional,requiredif='Username'",
+			expected: condition{field: "Username"},
+			found:    true,
+		},
+		{
+			name:     "empty value",
+			tag:      "requiredif='Mode='",
+			expected: condition{field: "Mode", value: "", hasValue: true},
+			found:    true,
+		},
+		{
+			name:  "no condition",
+			tag:   "required",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := getRequiredIf(tt.tag)
+			if found != tt.found {
+				t.Errorf("Expected found=%v, got %v for tag '%s'", tt.found, found, tt.tag)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %+v, got %+v for tag '%s'", tt.expected, result, tt.tag)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Variable describes an environment variable read by a config struct
+type Variable struct {
+	Name        string   `json:"name"`
+	Field       string   `json:"field"`
+	Type        string   `json:"type"`
+	Required    bool     `json:"required"`
+	Condition   string   `json:"condition,omitempty"`
+	Default     *string  `json:"default,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Separator   string   `json:"separator,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Trim        bool     `json:"trim,omitempty"`
+	OmitEmpty   bool     `json:"omit_empty,omitempty"`
+	Unique      bool     `json:"unique,omitempty"`
+	DefaultPort string   `json:"default_port,omitempty"`
+	Transforms  []string `json:"transforms,omitempty"`
+	Units       bool     `json:"units,omitempty"`
+	Min         string   `json:"min,omitempty"`
+	Max         string   `json:"max,omitempty"`
+	Secret      bool     `json:"secret,omitempty"`
+	Description string   `json:"description,omitempty"`
+
+	// Defaults for specific profiles, e.g. `dev`
+	ProfileDefaults map[string]string `json:"profile_defaults,omitempty"`
+
+	// The words accepted by a boolean field, if it sets them, e.g. `extended`
+	BoolVocabulary string `json:"bool_vocabulary,omitempty"`
+}
+
+// Description lists the environment variables read by a config struct, in the
+// order in which the fields are declared
+type Description []Variable
+
+// Describe returns the environment variables read by the config struct,
+// including the ones of nested structs
+func Describe(config any) Description {
+	t := reflect.TypeOf(config)
+	if t.Kind() != reflect.Struct {
+		panic("Invalid parameter")
+	}
+
+	return describeStruct(t, scope{})
+}
+
+func describeStruct(t reflect.Type, s scope) Description {
+	var description Description
+
+	for n := 0; n < t.NumField(); n++ {
+		field := t.Field(n)
+		if isNestedStruct(field.Type) {
+			description = append(description, describeStruct(field.Type, s.nested(field))...)
+			continue
+		}
+
+		tag := field.Tag.Get("env")
+		variable := Variable{
+			Name:        s.envVarName(field),
+			Field:       s.key(field),
+			Type:        typeName(field.Type),
+			Required:    !isOptional(tag) && !isConditional(tag),
+			Condition:   describeCondition(tag),
+			Values:      allowedValues(field),
+			Secret:      isSecret(tag),
+			Description: getDescription(tag),
+
+			ProfileDefaults: getProfileDefaults(tag),
+		}
+		if isOptional(tag) && hasDefault(tag) {
+			value := getDefault(tag)
+			variable.Default = &value
+		}
+		variable.DefaultPort, _ = defaultPortFor(field)
+		variable.Transforms = transformsFor(field).names()
+		variable.Units = unitsFor(field)
+		if b, ok := boundsFor(field); ok {
+			variable.Min, variable.Max = b.min, b.max
+		}
+		if vocabulary, ok := getBoolVocabulary(tag); ok {
+			variable.BoolVocabulary = vocabulary.String()
+		}
+		if field.Type.Kind() == reflect.Slice {
+			variable.Separator = getSeparator(tag)
+			variable.Format = getFormat(tag)
+			variable.Trim = !isNoTrim(tag)
+			variable.OmitEmpty = isOmitEmpty(tag)
+			variable.Unique = isUnique(tag)
+		}
+
+		description = append(description, variable)
+	}
+
+	return description
+}
+
+// Returns the name of the type without the package, e.g. `IPv4` or `[]int`
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Slice {
+		return "[]" + typeName(t.Elem())
+	}
+	if t.Name() == "" {
+		return t.String()
+	}
+	return t.Name()
+}
+
+// Describes when a conditional field is required
+func describeCondition(tag string) string {
+	var conditions []string
+	if cond, ok := getRequiredIf(tag); ok {
+		conditions = append(conditions, "required when "+cond.String())
+	}
+	if cond, ok := getRequiredUnless(tag); ok {
+		conditions = append(conditions, "required unless "+cond.String())
+	}
+	if group := getOneOf(tag); group != "" {
+		conditions = append(conditions, fmt.Sprintf("one of group '%s' is required", group))
+	}
+	if group := getExclusive(tag); group != "" {
+		conditions = append(conditions, fmt.Sprintf("at most one of group '%s'", group))
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// Returns the requirement as shown in the rendered tables
+func (v Variable) requirement() string {
+	switch {
+	case v.Condition != "":
+		return v.Condition
+	case v.Required:
+		return "required"
+	default:
+		return "optional"
+	}
+}
+
+// Returns the type as shown in the rendered tables, including the separator
+// and format of slices
+func (v Variable) typeWithSeparator() string {
+	if v.Separator == "" {
+		return v.Type
+	}
+	if v.Format != "" {
+		return fmt.Sprintf("%s (%s, separator '%s')", v.Type, v.Format, escapeSeparator(v.Separator))
+	}
+	return fmt.Sprintf("%s (separator '%s')", v.Type, escapeSeparator(v.Separator))
+}
+
+// Markdown renders the description as a Markdown table. Defaults of secrets
+// are not shown.
+func (d Description) Markdown() string {
+	cell := func(value string) string {
+		return strings.ReplaceAll(value, "|", `\|`)
+	}
+	code := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return "`" + cell(value) + "`"
+	}
+
+	var b strings.Builder
+	b.WriteString("| Variable | Type | Required | Default | Allowed values | Description |\n")
+	b.WriteString("|----------|------|----------|---------|----------------|-------------|\n")
+	for _, v := range d {
+		defaultValue := ""
+		if v.Default != nil {
+			defaultValue = code(*v.Default)
+			if *v.Default == "" {
+				defaultValue = "`\"\"`"
+			}
+			if v.Secret {
+				defaultValue = "(secret)"
+			}
+		}
+
+		values := make([]string, len(v.Values))
+		for i, value := range v.Values {
+			values[i] = code(value)
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			code(v.Name), code(v.typeWithSeparator()), cell(v.requirement()),
+			defaultValue, strings.Join(values, ", "), cell(v.Description))
+	}
+
+	return b.String()
+}
+
+// Text renders the description as a plain text table with aligned columns.
+// Defaults of secrets are not shown.
+func (d Description) Text() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "VARIABLE\tTYPE\tREQUIRED\tDEFAULT\tALLOWED VALUES\tDESCRIPTION")
+	for _, v := range d {
+		defaultValue := "-"
+		if v.Default != nil {
+			defaultValue = fmt.Sprintf("%q", *v.Default)
+			if v.Secret {
+				defaultValue = "(secret)"
+			}
+		}
+		values := "-"
+		if len(v.Values) > 0 {
+			values = strings.Join(v.Values, ",")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			v.Name, v.typeWithSeparator(), v.requirement(), defaultValue, values, v.Description)
+	}
+	w.Flush()
+
+	return b.String()
+}
+
+// JSON renders the description as a JSON array of variables
+func (d Description) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
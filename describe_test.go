@@ -0,0 +1,133 @@
+package env
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type TestDescribeDatabase struct {
+	Host string `env:"required,desc='Database host'"`
+}
+
+type TestDescribeConfig struct {
+	Port        int      `env:"optional,default='8080',desc='Port to listen on'"`
+	Environment string   `env:"required,values='dev,staging,prod',name='APP_ENV'"`
+	Hosts       []string `env:"optional,separator='|',default=''"`
+	TLSCert     string   `env:"requiredif='Environment=prod'"`
+	Database    TestDescribeDatabase
+}
+
+func TestDescribe(t *testing.T) {
+	description := Describe(TestDescribeConfig{})
+
+	if len(description) != 5 {
+		t.Fatalf("Expected 5 variables, got %d: %+v", len(description), description)
+	}
+
+	port := description[0]
+	if port.Name != "PORT" || port.Type != "int" || port.Required {
+		t.Errorf("Unexpected variable: %+v", port)
+	}
+	if port.Default == nil || *port.Default != "8080" {
+		t.Errorf("Expected default '8080', got %v", port.Default)
+	}
+	if port.Description != "Port to listen on" {
+		t.Errorf("Expected description 'Port to listen on', got '%s'", port.Description)
+	}
+
+	environment := description[1]
+	if environment.Name != "APP_ENV" || !environment.Required || environment.Default != nil {
+		t.Errorf("Unexpected variable: %+v", environment)
+	}
+	if strings.Join(environment.Values, ",") != "dev,staging,prod" {
+		t.Errorf("Expected values 'dev,staging,prod', got %v", environment.Values)
+	}
+
+	hosts := description[2]
+	if hosts.Type != "[]string" || hosts.Separator != "|" {
+		t.Errorf("Unexpected variable: %+v", hosts)
+	}
+	if hosts.Default == nil || *hosts.Default != "" {
+		t.Errorf("Expected empty default, got %v", hosts.Default)
+	}
+
+	tlsCert := description[3]
+	if tlsCert.Required || tlsCert.Condition != "required when Environment=prod" {
+		t.Errorf("Unexpected variable: %+v", tlsCert)
+	}
+
+	host := description[4]
+	if host.Name != "DATABASE_HOST" || host.Field != "Database.Host" {
+		t.Errorf("Unexpected variable: %+v", host)
+	}
+}
+
+func TestDescriptionMarkdown(t *testing.T) {
+	markdown := Describe(TestDescribeConfig{}).Markdown()
+
+	expected := []string{
+		"| Variable | Type | Required | Default | Allowed values | Description |",
+		"| `PORT` | `int` | optional | `8080` |  | Port to listen on |",
+		"| `APP_ENV` | `string` | required |  | `dev`, `staging`, `prod` |  |",
+		"| `HOSTS` | `[]string (separator '\\|')` | optional | `\"\"` |  |  |",
+		"| `TLSCERT` | `string` | required when Environment=prod |  |  |  |",
+	}
+	for _, line := range expected {
+		if !strings.Contains(markdown, line+"\n") {
+			t.Errorf("Expected markdown to contain line '%s', got:\n%s", line, markdown)
+		}
+	}
+}
+
+func TestDescriptionHidesSecretDefaults(t *testing.T) {
+	description := Describe(TestExampleConfig{})
+
+	if !description[2].Secret {
+		t.Fatalf("Expected DB_PASSWORD to be secret: %+v", description[2])
+	}
+	for name, rendered := range map[string]string{"markdown": description.Markdown(), "text": description.Text()} {
+		if strings.Contains(rendered, "changeme") {
+			t.Errorf("Expected %s not to contain the secret default:\n%s", name, rendered)
+		}
+	}
+}
+
+func TestDescriptionText(t *testing.T) {
+	text := Describe(TestDescribeConfig{}).Text()
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 lines, got %d:\n%s", len(lines), text)
+	}
+	if !strings.HasPrefix(lines[0], "VARIABLE") {
+		t.Errorf("Expected header, got '%s'", lines[0])
+	}
+
+	// Columns are aligned
+	column := strings.Index(lines[0], "TYPE")
+	for _, line := range lines[1:] {
+		if line[column-1] != ' ' || line[column] == ' ' {
+			t.Errorf("Expected TYPE column at %d in '%s'", column, line)
+		}
+	}
+}
+
+func TestDescriptionJSON(t *testing.T) {
+	data, err := Describe(TestDescribeConfig{}).JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+
+	var variables []map[string]any
+	if err := json.Unmarshal(data, &variables); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if variables[0]["name"] != "PORT" || variables[0]["default"] != "8080" {
+		t.Errorf("Unexpected variable: %v", variables[0])
+	}
+	if _, ok := variables[1]["default"]; ok {
+		t.Errorf("Expected no default for required variable: %v", variables[1])
+	}
+}
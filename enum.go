@@ -0,0 +1,124 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Enum can be implemented by named string types to declare their allowed
+// values, which are usually the constants of the type:
+//
+//	type Environment string
+//
+//	const (
+//		Dev  Environment = "dev"
+//		Prod Environment = "prod"
+//	)
+//
+//	func (Environment) EnvValues() []string { return []string{"dev", "prod"} }
+//
+// Types that are not strings, like the ones declared with iota, are registered
+// with RegisterEnum instead.
+type Enum interface {
+	EnvValues() []string
+}
+
+// The allowed values of an enum type, in the order in which they are shown,
+// and the constant of each
+type enum struct {
+	names  []string
+	values map[string]reflect.Value
+}
+
+func (e *enum) parse(value string) (any, error) {
+	v, ok := e.values[value]
+	if !ok {
+		return nil, fmt.Errorf("invalid value %s", value)
+	}
+	return v.Interface(), nil
+}
+
+// Enums by type, either registered or built from their EnvValues method
+var enums sync.Map
+
+// RegisterEnum declares the allowed values of the type T, keyed by the value
+// of the environment variable. It is meant for types that are not strings:
+//
+//	type Level int
+//
+//	const (
+//		Debug Level = iota
+//		Info
+//	)
+//
+//	func init() {
+//		env.RegisterEnum(map[string]Level{"debug": Debug, "info": Info})
+//	}
+//
+// Registering a type twice replaces its values.
+func RegisterEnum[T any](values map[string]T) {
+	if len(values) == 0 {
+		panic("RegisterEnum needs at least one value")
+	}
+
+	e := &enum{values: make(map[string]reflect.Value, len(values))}
+	for name, value := range values {
+		e.names = append(e.names, name)
+		e.values[name] = reflect.ValueOf(value)
+	}
+	sort.Strings(e.names)
+
+	enums.Store(reflect.TypeOf((*T)(nil)).Elem(), e)
+}
+
+// Returns the enum of the type, if it is one
+func enumFor(t reflect.Type) (*enum, bool) {
+	if e, ok := enums.Load(t); ok {
+		return e.(*enum), true
+	}
+
+	source, ok := reflect.Zero(t).Interface().(Enum)
+	if !ok {
+		if !reflect.PointerTo(t).Implements(reflect.TypeOf((*Enum)(nil)).Elem()) {
+			return nil, false
+		}
+		source = reflect.New(t).Interface().(Enum)
+	}
+	if t.Kind() != reflect.String {
+		panic(fmt.Sprintf("Type '%s' implements EnvValues but is not a string, use RegisterEnum instead", t))
+	}
+
+	e := &enum{names: source.EnvValues(), values: make(map[string]reflect.Value)}
+	if len(e.names) == 0 {
+		panic(fmt.Sprintf("EnvValues of type '%s' returns no values", t))
+	}
+	for _, name := range e.names {
+		e.values[name] = reflect.ValueOf(name).Convert(t)
+	}
+
+	enums.Store(t, e)
+	return e, true
+}
+
+// Returns the enum of the field, or of the elements of a slice field
+func enumForField(field reflect.StructField) (*enum, bool) {
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return enumFor(t)
+}
+
+// Returns the values allowed by the tag or, if it has none, by the enum type
+// of the field
+func allowedValues(field reflect.StructField) []string {
+	if values := getValues(field.Tag.Get("env")); values != nil {
+		return values
+	}
+	if e, ok := enumForField(field); ok {
+		return e.names
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package env
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testEnvironment string
+
+const (
+	testDev     testEnvironment = "dev"
+	testStaging testEnvironment = "staging"
+	testProd    testEnvironment = "prod"
+)
+
+func (testEnvironment) EnvValues() []string {
+	return []string{string(testDev), string(testStaging), string(testProd)}
+}
+
+type testLevel int
+
+const (
+	testDebug testLevel = iota
+	testInfo
+	testWarn
+)
+
+type testRegion string
+
+func (*testRegion) EnvValues() []string {
+	return []string{"eu", "us"}
+}
+
+func init() {
+	RegisterEnum(map[string]testLevel{"debug": testDebug, "info": testInfo, "warn": testWarn})
+}
+
+type TestConfigEnum struct {
+	Environment testEnvironment   `env:"required,name='ENUMENV'"`
+	Level       testLevel         `env:"optional,default='info',name='ENUMLEVEL'"`
+	Region      testRegion        `env:"optional,name='ENUMREGION'"`
+	Stages      []testEnvironment `env:"optional,separator=',',name='ENUMSTAGES'"`
+	Public      testEnvironment   `env:"optional,values='staging,prod',name='ENUMPUBLIC'"`
+}
+
+func TestAssertEnum(t *testing.T) {
+	t.Setenv("ENUMENV", "staging")
+	t.Setenv("ENUMREGION", "eu")
+	t.Setenv("ENUMSTAGES", "dev, prod")
+
+	config, err := Assert(TestConfigEnum{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.Environment != testStaging {
+		t.Errorf("Expected %v, got %v", testStaging, config.Environment)
+	}
+	if config.Level != testInfo {
+		t.Errorf("Expected the default level %v, got %v", testInfo, config.Level)
+	}
+	if config.Region != "eu" {
+		t.Errorf("Expected region eu, got %v", config.Region)
+	}
+	if !reflect.DeepEqual(config.Stages, []testEnvironment{testDev, testProd}) {
+		t.Errorf("Unexpected stages: %v", config.Stages)
+	}
+}
+
+func TestValidateEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		invalid string
+		values  []string
+	}{
+		{
+			name:    "value not in the constants",
+			envVars: map[string]string{"ENUMENV": "production"},
+			invalid: "Environment (ENUMENV)",
+			values:  []string{"dev", "staging", "prod"},
+		},
+		{
+			name:    "values are case sensitive",
+			envVars: map[string]string{"ENUMENV": "Dev"},
+			invalid: "Environment (ENUMENV)",
+			values:  []string{"dev", "staging", "prod"},
+		},
+		{
+			name:    "registered enum",
+			envVars: map[string]string{"ENUMENV": "dev", "ENUMLEVEL": "trace"},
+			invalid: "Level (ENUMLEVEL)",
+			values:  []string{"debug", "info", "warn"},
+		},
+		{
+			name:    "slice element",
+			envVars: map[string]string{"ENUMENV": "dev", "ENUMSTAGES": "dev,qa"},
+			invalid: "Stages (ENUMSTAGES)",
+			values:  []string{"dev", "staging", "prod"},
+		},
+		{
+			name:    "values narrowing the enum",
+			envVars: map[string]string{"ENUMENV": "dev", "ENUMPUBLIC": "dev"},
+			invalid: "Public (ENUMPUBLIC)",
+			values:  []string{"staging", "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigEnum{}, tt.envVars)
+			if len(report.Invalid) != 1 || report.Invalid[0].label() != tt.invalid {
+				t.Fatalf("Expected %s to be invalid, got %+v", tt.invalid, report.Invalid)
+			}
+			if !reflect.DeepEqual(report.Invalid[0].Values, tt.values) {
+				t.Errorf("Expected allowed values %v, got %v", tt.values, report.Invalid[0].Values)
+			}
+			if !strings.Contains(report.Render(false), "allowed: "+strings.Join(tt.values, ", ")) {
+				t.Errorf("Expected the allowed values in the message, got:\n%s", report.Render(false))
+			}
+		})
+	}
+}
+
+func TestDescribeEnum(t *testing.T) {
+	description := Describe(TestConfigEnum{})
+	expected := map[string][]string{
+		"ENUMENV":    {"dev", "staging", "prod"},
+		"ENUMLEVEL":  {"debug", "info", "warn"},
+		"ENUMREGION": {"eu", "us"},
+		"ENUMSTAGES": {"dev", "staging", "prod"},
+		"ENUMPUBLIC": {"staging", "prod"},
+	}
+	for _, v := range description {
+		if !reflect.DeepEqual(v.Values, expected[v.Name]) {
+			t.Errorf("Expected values %v for %s, got %v", expected[v.Name], v.Name, v.Values)
+		}
+	}
+
+	schema := NewSchema(TestConfigEnum{})
+	if !reflect.DeepEqual(schema.Properties["ENUMENV"].Enum, []string{"dev", "staging", "prod"}) {
+		t.Errorf("Expected the constants in the schema, got %v", schema.Properties["ENUMENV"].Enum)
+	}
+}
+
+type testColor int
+
+func (testColor) EnvValues() []string {
+	return []string{"red"}
+}
+
+func TestEnumPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+	}{
+		{
+			name: "default not in the enum",
+			config: struct {
+				Environment testEnvironment `env:"optional,default='qa',name='ENUMENV'"`
+			}{},
+		},
+		{
+			name: "EnvValues on a type that is not a string",
+			config: struct {
+				Color testColor `env:"required,name='ENUMCOLOR'"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic")
+				}
+			}()
+			ValidateMap(tt.config, map[string]string{"ENUMCOLOR": "red"})
+		})
+	}
+}
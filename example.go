@@ -0,0 +1,68 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteExample writes a dotenv file with every variable read by the config
+// struct, to be used as a template like `.env.example`. Each variable is
+// preceded by comments with its description, type, whether it is required and
+// its allowed values. Defaults are filled in, except for secrets, which are
+// always left blank.
+func WriteExample(w io.Writer, config any) error {
+	for i, v := range Describe(config) {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, v.example()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the lines of the example file for the variable
+func (v Variable) example() string {
+	var b strings.Builder
+
+	if v.Description != "" {
+		fmt.Fprintf(&b, "# %s\n", v.Description)
+	}
+
+	details := []string{v.typeWithSeparator(), v.requirement()}
+	if v.Secret {
+		details = append(details, "secret")
+	}
+	if len(v.Values) > 0 {
+		details = append(details, "one of: "+strings.Join(v.Values, ", "))
+	}
+	if v.Min != "" {
+		details = append(details, "min "+v.Min)
+	}
+	if v.Max != "" {
+		details = append(details, "max "+v.Max)
+	}
+	fmt.Fprintf(&b, "# %s\n", strings.Join(details, ", "))
+
+	value := ""
+	if v.Default != nil && !v.Secret {
+		value = quoteDotenv(*v.Default)
+	}
+	fmt.Fprintf(&b, "%s=%s\n", v.Name, value)
+
+	return b.String()
+}
+
+// Quotes the value when it would not be read back as is from a dotenv file
+func quoteDotenv(value string) string {
+	if value != strings.TrimSpace(value) || strings.ContainsAny(value, "#\"'\n\\") {
+		return strconv.Quote(value)
+	}
+	return value
+}
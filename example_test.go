@@ -0,0 +1,64 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestExampleConfig struct {
+	Port        int      `env:"optional,default='8080',desc='Port to listen on'"`
+	Environment string   `env:"required,values='dev,staging,prod',name='APP_ENV'"`
+	Password    string   `env:"optional,secret,default='changeme',name='DB_PASSWORD'"`
+	Greeting    string   `env:"optional,default='hello # world'"`
+	Hosts       []string `env:"optional,separator=',',default='a,b'"`
+}
+
+func TestWriteExample(t *testing.T) {
+	var b strings.Builder
+	if err := WriteExample(&b, TestExampleConfig{}); err != nil {
+		t.Fatalf("WriteExample failed: %v", err)
+	}
+
+	expected := `# Port to listen on
+# int, optional
+PORT=8080
+
+# string, required, one of: dev, staging, prod
+APP_ENV=
+
+# string, optional, secret
+DB_PASSWORD=
+
+# string, optional
+GREETING="hello # world"
+
+# []string (separator ','), optional
+HOSTS=a,b
+`
+	if b.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, b.String())
+	}
+}
+
+func TestWriteExampleRoundTrip(t *testing.T) {
+	var b strings.Builder
+	WriteExample(&b, TestExampleConfig{})
+
+	// Filling in the blanks of the example produces a valid config
+	content := strings.Replace(b.String(), "APP_ENV=\n", "APP_ENV=dev\n", 1)
+	path := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(path, []byte(content), 0o600)
+
+	config, err := Assert(TestExampleConfig{}, WithDotenv(path))
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+	if config.Greeting != "hello # world" {
+		t.Errorf("Expected 'hello # world', got '%s'", config.Greeting)
+	}
+	if config.Password != "changeme" {
+		t.Errorf("Expected the default password, got '%s'", config.Password)
+	}
+}
@@ -0,0 +1,46 @@
+package env
+
+import (
+	"fmt"
+	"io"
+)
+
+// ExitConfig is the exit code for configuration errors, EX_CONFIG in sysexits.h
+const ExitConfig = 78
+
+// MustAssertOrExit validates environment variables and returns a populated
+// struct instance. If validation fails it writes the report to stderr and exits
+// with code 78 (EX_CONFIG), instead of panicking with a stack trace like
+// MustAssert. The exit code, the output and the exit function can be changed
+// with WithExitCode, WithErrorOutput and WithExitFunc, and WithBeforeExit runs
+// a function before exiting, e.g. to flush logs.
+//
+// If the exit function returns, the zero value of the struct is returned.
+func MustAssertOrExit[T any](config T, opts ...Option) T {
+	result, err := Assert(config, opts...)
+	if err == nil {
+		return result
+	}
+
+	o := newOptions(opts)
+	writeError(o.errorOutput, err)
+
+	for _, fn := range o.beforeExit {
+		fn()
+	}
+	o.exit(o.exitCode)
+
+	var zero T
+	return zero
+}
+
+// Writes the report of the error, or its message if it is not a validation
+// error
+func writeError(w io.Writer, err error) {
+	if validationErr, ok := err.(*ValidationError); ok {
+		fmt.Fprintln(w, "Configuration error:")
+		validationErr.WriteTo(w)
+		return
+	}
+	fmt.Fprintf(w, "Configuration error: %v\n", err)
+}
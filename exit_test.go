@@ -0,0 +1,81 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestExitConfig struct {
+	Port int `env:"required,name='EXIT_PORT'"`
+}
+
+func TestMustAssertOrExit(t *testing.T) {
+	var output strings.Builder
+	var calls []string
+	code := -1
+
+	config := MustAssertOrExit(TestExitConfig{},
+		WithErrorOutput(&output),
+		WithBeforeExit(func() { calls = append(calls, "flush") }),
+		WithExitFunc(func(c int) {
+			calls = append(calls, "exit")
+			code = c
+		}),
+	)
+
+	if code != ExitConfig {
+		t.Errorf("Expected exit code %d, got %d", ExitConfig, code)
+	}
+	if strings.Join(calls, ",") != "flush,exit" {
+		t.Errorf("Expected flush before exit, got %v", calls)
+	}
+	if config.Port != 0 {
+		t.Errorf("Expected zero value, got %+v", config)
+	}
+
+	expected := "Configuration error:\nMissing variables (1):\n  Port (EXIT_PORT)  int\n"
+	if output.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, output.String())
+	}
+}
+
+func TestMustAssertOrExitCode(t *testing.T) {
+	code := -1
+	MustAssertOrExit(TestExitConfig{},
+		WithErrorOutput(&strings.Builder{}),
+		WithExitCode(3),
+		WithExitFunc(func(c int) { code = c }),
+	)
+
+	if code != 3 {
+		t.Errorf("Expected exit code 3, got %d", code)
+	}
+}
+
+func TestMustAssertOrExitSourceError(t *testing.T) {
+	var output strings.Builder
+	MustAssertOrExit(TestExitConfig{},
+		WithDotenv(filepath.Join(t.TempDir(), "missing.env")),
+		WithErrorOutput(&output),
+		WithExitFunc(func(int) {}),
+	)
+
+	if !strings.HasPrefix(output.String(), "Configuration error: open ") {
+		t.Errorf("Unexpected output: %s", output.String())
+	}
+}
+
+func TestMustAssertOrExitValid(t *testing.T) {
+	os.Setenv("EXIT_PORT", "8080")
+	defer os.Unsetenv("EXIT_PORT")
+
+	config := MustAssertOrExit(TestExitConfig{}, WithExitFunc(func(int) {
+		t.Errorf("Expected no exit")
+	}))
+
+	if config.Port != 8080 {
+		t.Errorf("Expected 8080, got %d", config.Port)
+	}
+}
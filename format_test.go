@@ -0,0 +1,141 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+type TestFormatConfig struct {
+	DatabaseURL string `env:"required,name='DATABASE_URL'"`
+	Port        int    `env:"required"`
+	Environment string `env:"required,values='dev,staging,prod',name='APP_ENV'"`
+	Password    string `env:"required,secret,values='a,b'"`
+	ListenAddr  IPv4   `env:"required"`
+}
+
+func setFormatEnv(t *testing.T) {
+	t.Helper()
+	envVars := map[string]string{
+		"PORT":       "http",
+		"APP_ENV":    "production",
+		"PASSWORD":   "hunter2",
+		"LISTENADDR": "10.0.0",
+	}
+	for key, value := range envVars {
+		os.Setenv(key, value)
+	}
+	t.Cleanup(func() {
+		for key := range envVars {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestValidationErrorRender(t *testing.T) {
+	setFormatEnv(t)
+
+	_, err := Assert(TestFormatConfig{})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected *ValidationError, got %T: %v", err, err)
+	}
+
+	expected := `Missing variables (1):
+  DatabaseURL (DATABASE_URL)  string
+Invalid variables (4):
+  Port (PORT)  int, got "http"
+      hint: expected an integer like 8080
+  Environment (APP_ENV)  string, got "production"
+      allowed: dev, staging, prod
+  Password (PASSWORD)  string, got "****"
+      allowed: a, b
+  ListenAddr (LISTENADDR)  IPv4, got "10.0.0"
+      hint: expected IPv4 like 10.0.0.1
+`
+	if rendered := validationErr.Render(false); rendered != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, rendered)
+	}
+
+	if formatted := fmt.Sprintf("%+v", err); formatted != expected {
+		t.Errorf("Expected %%+v to render the report, got:\n%s", formatted)
+	}
+
+	colored := validationErr.Render(true)
+	if !strings.Contains(colored, colorRed) || !strings.Contains(colored, colorReset) {
+		t.Errorf("Expected ANSI colors, got:\n%q", colored)
+	}
+
+	if strings.Contains(colored, "hunter2") {
+		t.Errorf("Expected secret to be masked, got:\n%s", colored)
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	setFormatEnv(t)
+
+	_, err := Assert(TestFormatConfig{})
+	message := err.Error()
+
+	if !strings.HasPrefix(message, "Missing: [DatabaseURL (DATABASE_URL)]\nInvalid: [{Port (PORT) http}") {
+		t.Errorf("Unexpected error message: %s", message)
+	}
+	if strings.Contains(message, "hunter2") {
+		t.Errorf("Expected secret to be masked, got: %s", message)
+	}
+	if fmt.Sprintf("%v", err) != message {
+		t.Errorf("Expected %%v to be the error message")
+	}
+}
+
+func TestValidationErrorWriteTo(t *testing.T) {
+	setFormatEnv(t)
+
+	_, err := Assert(TestFormatConfig{})
+
+	var b strings.Builder
+	err.(*ValidationError).WriteTo(&b)
+	if strings.Contains(b.String(), "\033[") {
+		t.Errorf("Expected no colors when not writing to a terminal, got:\n%q", b.String())
+	}
+}
+
+func TestMustAssertPanicMessage(t *testing.T) {
+	setFormatEnv(t)
+
+	defer func() {
+		r := recover()
+		message, ok := r.(string)
+		if !ok {
+			t.Fatalf("Expected panic with a string, got %v", r)
+		}
+		if !strings.HasPrefix(message, "Configuration error:\nMissing variables (1):\n") {
+			t.Errorf("Unexpected panic message:\n%s", message)
+		}
+	}()
+
+	MustAssert(TestFormatConfig{})
+}
+
+func TestHintFor(t *testing.T) {
+	tests := []struct {
+		fieldType string
+		expected  string
+	}{
+		{"IPv4", "expected IPv4 like 10.0.0.1"},
+		{"[]int", "each element expected an integer like 8080"},
+		{"string", ""},
+		{"bool", ""},
+		{"[]string", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fieldType, func(t *testing.T) {
+			if result := hintFor(tt.fieldType); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
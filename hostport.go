@@ -0,0 +1,120 @@
+package env
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Type: HostPort
+func hostPortParser(value string) (HostPort, error) {
+	host, portStr, err := net.SplitHostPort(value)
+	if err != nil {
+		return HostPort{}, fmt.Errorf("invalid address %s: %v", value, err)
+	}
+	if portStr == "" {
+		return HostPort{}, fmt.Errorf("invalid address %s: missing port", value)
+	}
+
+	port, err := parsePort(portStr)
+	if err != nil {
+		return HostPort{}, fmt.Errorf("invalid address %s: %v", value, err)
+	}
+
+	// An empty host listens on every interface, e.g. `:8080`
+	if host != "" && !isValidHost(host) {
+		return HostPort{}, fmt.Errorf("invalid address %s: invalid host '%s'", value, host)
+	}
+
+	return HostPort{host: host, port: port}, nil
+}
+
+// Parses a port number between 1 and 65535
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || strings.HasPrefix(value, "0") || strings.HasPrefix(value, "+") {
+		return 0, fmt.Errorf("invalid port '%s'", value)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range 1-65535", port)
+	}
+	return port, nil
+}
+
+// Hosts are either IP addresses or names like `db` or `db.internal`
+func isValidHost(host string) bool {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	for _, c := range host {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// HostPort is a network address like `0.0.0.0:8080`, `[::1]:5432` or
+// `db:5432`. The port can be left out if the field has a default port, e.g.
+// `defaultport='5432'`.
+type HostPort struct {
+	host string
+	port int
+}
+
+// Host returns the host without brackets, e.g. `::1` for `[::1]:5432`
+func (h HostPort) Host() string {
+	return h.host
+}
+
+// Port returns the port, between 1 and 65535
+func (h HostPort) Port() int {
+	return h.port
+}
+
+// String returns the address as accepted by net.Dial and net.Listen
+func (h HostPort) String() string {
+	if h.port == 0 {
+		return ""
+	}
+	return net.JoinHostPort(h.host, strconv.Itoa(h.port))
+}
+
+// Returns the default port of the field, if any. The option is only valid on
+// HostPort fields, using it anywhere else is a programming error.
+func defaultPortFor(field reflect.StructField) (string, bool) {
+	port, ok := getDefaultPort(field.Tag.Get("env"))
+	if !ok {
+		return "", false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t != reflect.TypeOf(HostPort{}) {
+		panic(fmt.Sprintf("Option 'defaultport' of field '%s' is only valid for HostPort fields", field.Name))
+	}
+
+	return port, true
+}
+
+// Adds the port to the address if it has none. IPv6 addresses may be given
+// with or without brackets, e.g. `[::1]` or `::1`.
+func withDefaultPort(value string, port string) string {
+	if value == "" {
+		return value
+	}
+	if _, _, err := net.SplitHostPort(value); err == nil {
+		return value
+	}
+
+	host := value
+	if strings.HasPrefix(host, "[") && strings.HasSuffix(host, "]") {
+		host = host[1 : len(host)-1]
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,225 @@
+package env
+
+import (
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestHostPortParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		host        string
+		port        int
+		expectError bool
+	}{
+		{name: "IPv4", value: "0.0.0.0:8080", host: "0.0.0.0", port: 8080},
+		{name: "IPv6 in brackets", value: "[::1]:5432", host: "::1", port: 5432},
+		{name: "IPv6 with zone", value: "[fe80::1%eth0]:443", host: "fe80::1%eth0", port: 443},
+		{name: "hostname", value: "db:5432", host: "db", port: 5432},
+		{name: "qualified hostname", value: "db.internal:5432", host: "db.internal", port: 5432},
+		{name: "every interface", value: ":8080", host: "", port: 8080},
+		{name: "highest port", value: "db:65535", host: "db", port: 65535},
+		{name: "lowest port", value: "db:1", host: "db", port: 1},
+		{name: "port zero", value: "db:0", expectError: true},
+		{name: "port out of range", value: "db:65536", expectError: true},
+		{name: "negative port", value: "db:-1", expectError: true},
+		{name: "signed port", value: "db:+80", expectError: true},
+		{name: "leading zero", value: "db:080", expectError: true},
+		{name: "named port", value: "db:postgres", expectError: true},
+		{name: "missing port", value: "db", expectError: true},
+		{name: "empty port", value: "db:", expectError: true},
+		{name: "IPv6 without brackets", value: "::1:5432", expectError: true},
+		{name: "invalid host", value: "d b:5432", expectError: true},
+		{name: "empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := hostPortParser(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got %v", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if result.Host() != tt.host || result.Port() != tt.port {
+				t.Errorf("Expected host '%s' and port %d, got '%s' and %d", tt.host, tt.port, result.Host(), result.Port())
+			}
+			if result.String() != tt.value && tt.host != "" {
+				t.Errorf("Expected '%s', got '%s'", tt.value, result)
+			}
+		})
+	}
+}
+
+func TestWithDefaultPort(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"db", "db:5432"},
+		{"db:6543", "db:6543"},
+		{"10.0.0.1", "10.0.0.1:5432"},
+		{"::1", "[::1]:5432"},
+		{"[::1]", "[::1]:5432"},
+		{"[::1]:6543", "[::1]:6543"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if result := withDefaultPort(tt.value, "5432"); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigHostPort struct {
+	Listen   HostPort   `env:"required,name='HPLISTEN'"`
+	Database HostPort   `env:"required,defaultport='5432',name='HPDATABASE'"`
+	Replicas []HostPort `env:"optional,separator=',',defaultport='5432',name='HPREPLICAS'"`
+	Cache    HostPort   `env:"optional,default='localhost:6379',name='HPCACHE'"`
+}
+
+func TestAssertHostPort(t *testing.T) {
+	envVars := map[string]string{
+		"HPLISTEN":   "[::]:8080",
+		"HPDATABASE": "db",
+		"HPREPLICAS": "replica1, replica2:6543, ::1",
+	}
+	for key, value := range envVars {
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key := range envVars {
+			os.Unsetenv(key)
+		}
+	}()
+
+	config, err := Assert(TestConfigHostPort{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.Listen.Host() != "::" || config.Listen.Port() != 8080 {
+		t.Errorf("Unexpected listen address: %v", config.Listen)
+	}
+	if config.Database.String() != "db:5432" {
+		t.Errorf("Expected the default port, got %v", config.Database)
+	}
+	expected := []string{"replica1:5432", "replica2:6543", "[::1]:5432"}
+	if len(config.Replicas) != len(expected) {
+		t.Fatalf("Expected %d replicas, got %v", len(expected), config.Replicas)
+	}
+	for i, replica := range config.Replicas {
+		if replica.String() != expected[i] {
+			t.Errorf("Expected replica %d to be '%s', got '%s'", i, expected[i], replica)
+		}
+	}
+	if config.Cache.String() != "localhost:6379" {
+		t.Errorf("Expected the default cache address, got %v", config.Cache)
+	}
+}
+
+func TestValidateHostPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		invalid string
+	}{
+		{
+			name:    "missing port without a default",
+			envVars: map[string]string{"HPLISTEN": "localhost", "HPDATABASE": "db"},
+			invalid: "Listen (HPLISTEN)",
+		},
+		{
+			name:    "port out of range",
+			envVars: map[string]string{"HPLISTEN": ":8080", "HPDATABASE": "db:70000"},
+			invalid: "Database (HPDATABASE)",
+		},
+		{
+			name:    "invalid slice element",
+			envVars: map[string]string{"HPLISTEN": ":8080", "HPDATABASE": "db", "HPREPLICAS": "replica1,replica2:0"},
+			invalid: "Replicas (HPREPLICAS)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigHostPort{}, tt.envVars)
+			if len(report.Invalid) != 1 || report.Invalid[0].label() != tt.invalid {
+				t.Errorf("Expected %s to be invalid, got %+v", tt.invalid, report.Invalid)
+			}
+		})
+	}
+}
+
+func TestDefaultPortPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+	}{
+		{
+			name: "not a HostPort",
+			config: struct {
+				Port int `env:"required,defaultport='80',name='HPPORT'"`
+			}{},
+		},
+		{
+			name: "port out of range",
+			config: struct {
+				Addr HostPort `env:"required,defaultport='0',name='HPADDR'"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic")
+				}
+			}()
+			Describe(tt.config)
+		})
+	}
+}
+
+func TestSchemaHostPortPatterns(t *testing.T) {
+	schema := NewSchema(TestConfigHostPort{})
+
+	tests := []struct {
+		variable string
+		value    string
+		expected bool
+	}{
+		{"HPLISTEN", "0.0.0.0:8080", true},
+		{"HPLISTEN", "[::1]:5432", true},
+		{"HPLISTEN", ":8080", true},
+		{"HPLISTEN", "db:65535", true},
+		{"HPLISTEN", "db:65536", false},
+		{"HPLISTEN", "db:0", false},
+		{"HPLISTEN", "db", false},
+		{"HPDATABASE", "db", true},
+		{"HPDATABASE", "db:6543", true},
+		{"HPDATABASE", "::1", true},
+		{"HPDATABASE", "[::1]", true},
+		{"HPDATABASE", "db:70000", false},
+		{"HPREPLICAS", "replica1, replica2:6543", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable+"="+tt.value, func(t *testing.T) {
+			pattern := schema.Properties[tt.variable].Pattern
+			if matched := regexp.MustCompile(pattern).MatchString(tt.value); matched != tt.expected {
+				t.Errorf("Expected %v for '%s' with pattern '%s'", tt.expected, tt.value, pattern)
+			}
+		})
+	}
+}
@@ -198,21 +198,22 @@ func TestIntegration_ServerConfigWithDefaults(t *testing.T) {
 }
 
 func TestIntegration_ComplexConfig(t *testing.T) {
-	// Set up environment variables for complex config
+	// Set up environment variables for complex config. The fields of nested
+	// structs are prefixed with the name of the field.
 	envVars := map[string]string{
-		"AppName": "myapp",
-		"Version": "2.0.0",
+		"APPNAME": "myapp",
+		"VERSION": "2.0.0",
 		// Database config
-		"Database.Host":     "db.example.com",
-		"Database.Port":     "5432",
-		"Database.Username": "dbuser",
-		"Database.Password": "dbpass",
-		"Database.SSL":      "true",
+		"DATABASE_HOST":     "db.example.com",
+		"DATABASE_PORT":     "5432",
+		"DATABASE_USERNAME": "dbuser",
+		"DATABASE_PASSWORD": "dbpass",
+		"DATABASE_SSL":      "true",
 		// Server config
-		"Server.ListenAddr": "0.0.0.0",
-		"Server.Port":       "8080",
-		"Server.Debug":      "false",
-		"Server.LogLevel":   "warn",
+		"SERVER_LISTENADDR": "0.0.0.0",
+		"SERVER_PORT":       "8080",
+		"SERVER_DEBUG":      "false",
+		"SERVER_LOGLEVEL":   "warn",
 	}
 
 	for key, value := range envVars {
@@ -224,10 +225,32 @@ func TestIntegration_ComplexConfig(t *testing.T) {
 		}
 	}()
 
-	// Note: This test demonstrates the limitation of the current implementation
-	// which doesn't handle nested structs. In a real implementation, you'd need
-	// to handle nested struct field names properly.
-	t.Skip("Skipping complex config test - nested structs not supported in current implementation")
+	var config ComplexConfig
+	env, err := Assert(config)
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if env.AppName != "myapp" {
+		t.Errorf("Expected 'myapp', got '%s'", env.AppName)
+	}
+
+	if env.Database.Host != "db.example.com" {
+		t.Errorf("Expected 'db.example.com', got '%s'", env.Database.Host)
+	}
+
+	if env.Database.Port != 5432 {
+		t.Errorf("Expected 5432, got %d", env.Database.Port)
+	}
+
+	if env.Server.LogLevel != "warn" {
+		t.Errorf("Expected 'warn', got '%s'", env.Server.LogLevel)
+	}
+
+	// Defaults of nested structs are applied as well
+	if len(env.Server.AllowedIPs) != 2 {
+		t.Errorf("Expected 2 allowed IPs, got %d", len(env.Server.AllowedIPs))
+	}
 }
 
 // Helper function
@@ -0,0 +1,95 @@
+package env
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// Type: IPv6
+func ipv6Validator(value string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || !addr.Is6() {
+		return fmt.Errorf("invalid IPv6 address: %s", value)
+	}
+
+	return nil
+}
+
+func ipv6Parser(value string) (string, error) {
+	ok := ipv6Validator(value)
+	if ok != nil {
+		return "", ok
+	}
+	return value, nil
+}
+
+type IPv6 string
+
+// Type: IP, either IPv4 or IPv6
+func ipValidator(value string) error {
+	if _, err := netip.ParseAddr(value); err != nil {
+		return fmt.Errorf("invalid IP address: %s", value)
+	}
+
+	return nil
+}
+
+func ipParser(value string) (string, error) {
+	ok := ipValidator(value)
+	if ok != nil {
+		return "", ok
+	}
+	return value, nil
+}
+
+type IP string
+
+// Type: CIDR
+func cidrParser(value string) (CIDR, error) {
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return CIDR{}, fmt.Errorf("invalid CIDR: %s", value)
+	}
+
+	// An address within the network is most likely a mistake
+	if prefix != prefix.Masked() {
+		return CIDR{}, fmt.Errorf("invalid CIDR %s: host bits are set, did you mean %s?", value, prefix.Masked())
+	}
+
+	return CIDR{prefix}, nil
+}
+
+// CIDR is a network like `10.0.0.0/8` or `2001:db8::/32`. It embeds
+// netip.Prefix, so `Contains`, `Addr` and `Bits` can be used directly.
+type CIDR struct {
+	netip.Prefix
+}
+
+// Reports whether the allowed values of the type may be networks
+func isNetworkType(fieldType string) bool {
+	switch strings.ToLower(fieldType) {
+	case "ipv4", "ipv6", "ip", "cidr", "ipset":
+		return true
+	default:
+		return false
+	}
+}
+
+// Reports whether every address of the value, an address, a network or a
+// list of both, is within the allowed addresses and networks. A value that
+// is not valid is not allowed.
+func isWithinNetworks(value string, allowedValues []string) bool {
+	allowed, err := ipSetParser(strings.Join(allowedValues, ","))
+	if err != nil {
+		panic(fmt.Sprintf("Invalid allowed values %v: %v", allowedValues, err))
+	}
+
+	for _, element := range strings.Split(value, ",") {
+		prefix, err := parseIPOrCIDR(strings.TrimSpace(element))
+		if err != nil || !allowed.containsPrefix(prefix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package env
+
+import (
+	"net/netip"
+	"os"
+	"testing"
+)
+
+func TestIPv6Validator(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{name: "full address", value: "2001:0db8:0000:0000:0000:0000:0000:0001", expectError: false},
+		{name: "compressed address", value: "2001:db8::1", expectError: false},
+		{name: "loopback", value: "::1", expectError: false},
+		{name: "with zone", value: "fe80::1%eth0", expectError: false},
+		{name: "IPv4 mapped", value: "::ffff:10.0.0.1", expectError: false},
+		{name: "IPv4", value: "10.0.0.1", expectError: true},
+		{name: "too many groups", value: "1:2:3:4:5:6:7:8:9", expectError: true},
+		{name: "invalid characters", value: "2001:db8::g", expectError: true},
+		{name: "empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ipv6Validator(tt.value)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for value '%s' but got none", tt.value)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+		})
+	}
+}
+
+func TestIPValidator(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{name: "IPv4", value: "10.0.0.1", expectError: false},
+		{name: "IPv6", value: "2001:db8::1", expectError: false},
+		{name: "hostname", value: "localhost", expectError: true},
+		{name: "CIDR", value: "10.0.0.0/8", expectError: true},
+		{name: "empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ipValidator(tt.value)
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for value '%s' but got none", tt.value)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+		})
+	}
+}
+
+func TestCIDRParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{name: "IPv4 network", value: "10.0.0.0/8", expected: "10.0.0.0/8"},
+		{name: "IPv6 network", value: "2001:db8::/32", expected: "2001:db8::/32"},
+		{name: "single address", value: "10.0.0.1/32", expected: "10.0.0.1/32"},
+		{name: "host bits set", value: "10.0.0.1/8", expectError: true},
+		{name: "prefix too long", value: "10.0.0.0/33", expectError: true},
+		{name: "address without prefix", value: "10.0.0.0", expectError: true},
+		{name: "empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := cidrParser(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got %v", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigIP struct {
+	Bind     IP     `env:"required,name='IPBIND'"`
+	Gateway  IPv6   `env:"optional,name='IPGATEWAY'"`
+	Network  CIDR   `env:"optional,default='10.0.0.0/8',name='IPNETWORK'"`
+	Subnets  []CIDR `env:"optional,separator=',',name='IPSUBNETS'"`
+	Peers    []IP   `env:"optional,separator=',',values='10.0.0.0/8,2001:db8::/32',name='IPPEERS'"`
+	Admin    IPv4   `env:"optional,values='192.168.0.0/16,127.0.0.1',name='IPADMIN'"`
+	Internal CIDR   `env:"optional,values='10.0.0.0/8',name='IPINTERNAL'"`
+}
+
+func TestAssertIPTypes(t *testing.T) {
+	envVars := map[string]string{
+		"IPBIND":     "::",
+		"IPGATEWAY":  "fe80::1",
+		"IPSUBNETS":  "10.1.0.0/16, 2001:db8:1::/48",
+		"IPPEERS":    "10.0.0.5,2001:db8::5",
+		"IPADMIN":    "192.168.1.10",
+		"IPINTERNAL": "10.20.0.0/16",
+	}
+	for key, value := range envVars {
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key := range envVars {
+			os.Unsetenv(key)
+		}
+	}()
+
+	config, err := Assert(TestConfigIP{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.Bind != "::" || config.Gateway != "fe80::1" {
+		t.Errorf("Unexpected addresses: %+v", config)
+	}
+	if config.Network.String() != "10.0.0.0/8" || !config.Network.Contains(netip.MustParseAddr("10.1.2.3")) {
+		t.Errorf("Unexpected network: %v", config.Network)
+	}
+	if len(config.Subnets) != 2 || config.Subnets[1].Bits() != 48 {
+		t.Errorf("Unexpected subnets: %v", config.Subnets)
+	}
+	if len(config.Peers) != 2 || config.Peers[1] != "2001:db8::5" {
+		t.Errorf("Unexpected peers: %v", config.Peers)
+	}
+}
+
+func TestValidateNetworkAllowlists(t *testing.T) {
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		invalid string
+	}{
+		{
+			name:    "address outside the allowed networks",
+			envVars: map[string]string{"IPBIND": "::", "IPADMIN": "10.0.0.1"},
+			invalid: "Admin (IPADMIN)",
+		},
+		{
+			name:    "allowed single address",
+			envVars: map[string]string{"IPBIND": "::", "IPADMIN": "127.0.0.1"},
+		},
+		{
+			name:    "slice element outside the allowed networks",
+			envVars: map[string]string{"IPBIND": "::", "IPPEERS": "10.0.0.1,2001:db9::1"},
+			invalid: "Peers (IPPEERS)",
+		},
+		{
+			name:    "network larger than the allowed one",
+			envVars: map[string]string{"IPBIND": "::", "IPINTERNAL": "0.0.0.0/0"},
+			invalid: "Internal (IPINTERNAL)",
+		},
+		{
+			name:    "invalid CIDR",
+			envVars: map[string]string{"IPBIND": "::", "IPNETWORK": "10.0.0.1/8"},
+			invalid: "Network (IPNETWORK)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigIP{}, tt.envVars)
+
+			if tt.invalid == "" {
+				if !report.Valid() {
+					t.Errorf("Expected a valid report, got %+v", report)
+				}
+				return
+			}
+			if len(report.Invalid) != 1 || report.Invalid[0].label() != tt.invalid {
+				t.Errorf("Expected %s to be invalid, got %+v", tt.invalid, report.Invalid)
+			}
+		})
+	}
+}
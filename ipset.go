@@ -0,0 +1,169 @@
+package env
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// Type: IPSet
+func ipSetParser(value string) (IPSet, error) {
+	var prefixes []netip.Prefix
+
+	for i, element := range strings.Split(value, ",") {
+		element = strings.TrimSpace(element)
+		prefix, err := parseIPOrCIDR(element)
+		if err != nil {
+			return IPSet{}, fmt.Errorf("invalid IP set: element %d: %v", i, err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return NewIPSet(prefixes...), nil
+}
+
+// Parses an address as a single address network, e.g. `10.0.0.1/32`, or a
+// network. Host bits are allowed, `10.0.0.1/8` is the same as `10.0.0.0/8`.
+func parseIPOrCIDR(value string) (netip.Prefix, error) {
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR: %s", value)
+		}
+		return unmapPrefix(prefix).Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address: %s", value)
+	}
+	addr = addr.Unmap().WithZone("")
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// IPv4 addresses mapped to IPv6, like `::ffff:10.0.0.0/104`, are matched as
+// IPv4
+func unmapPrefix(prefix netip.Prefix) netip.Prefix {
+	addr := prefix.Addr()
+	if !addr.Is4In6() {
+		return prefix
+	}
+	bits := prefix.Bits() - 96
+	if bits < 0 {
+		bits = 0
+	}
+	return netip.PrefixFrom(addr.Unmap(), bits)
+}
+
+// IPSet is a set of addresses and networks, e.g. an allowlist like
+// `10.0.0.0/8,192.168.1.1,2001:db8::/32`. Lookups are a binary search over
+// the merged ranges of addresses.
+type IPSet struct {
+	prefixes []netip.Prefix
+	ranges   []ipRange
+}
+
+// A range of addresses of the same family, both ends included
+type ipRange struct {
+	from, to netip.Addr
+}
+
+// NewIPSet returns the set of the addresses in the given networks
+func NewIPSet(prefixes ...netip.Prefix) IPSet {
+	set := IPSet{prefixes: append([]netip.Prefix(nil), prefixes...)}
+
+	for _, prefix := range prefixes {
+		prefix = unmapPrefix(prefix).Masked()
+		set.ranges = append(set.ranges, ipRange{prefix.Addr(), lastAddr(prefix)})
+	}
+
+	// IPv4 addresses sort before IPv6 ones, so ranges never mix families
+	sort.Slice(set.ranges, func(i, j int) bool {
+		return set.ranges[i].from.Less(set.ranges[j].from)
+	})
+
+	merged := set.ranges[:0]
+	for _, r := range set.ranges {
+		if n := len(merged); n > 0 && adjoins(merged[n-1], r) {
+			if merged[n-1].to.Less(r.to) {
+				merged[n-1].to = r.to
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	set.ranges = merged
+
+	return set
+}
+
+// Reports whether the range b, which doesn't start before a, overlaps a or
+// starts right after it
+func adjoins(a, b ipRange) bool {
+	if a.from.BitLen() != b.from.BitLen() {
+		return false
+	}
+	next := a.to.Next()
+	return !next.IsValid() || !next.Less(b.from)
+}
+
+// Returns the last address of the network
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// Contains reports whether the address is in the set. IPv4 addresses mapped
+// to IPv6 match the IPv4 networks.
+func (s IPSet) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	return s.containsRange(addr, addr)
+}
+
+// Reports whether every address from `from` to `to` is in the set
+func (s IPSet) containsRange(from, to netip.Addr) bool {
+	// The first range that doesn't end before the address
+	i := sort.Search(len(s.ranges), func(i int) bool {
+		r := s.ranges[i]
+		return r.from.BitLen() > from.BitLen() || r.from.BitLen() == from.BitLen() && !r.to.Less(from)
+	})
+
+	if i == len(s.ranges) {
+		return false
+	}
+	r := s.ranges[i]
+	return r.from.BitLen() == from.BitLen() && !from.Less(r.from) && !r.to.Less(to)
+}
+
+// containsPrefix reports whether every address of the network is in the set
+func (s IPSet) containsPrefix(prefix netip.Prefix) bool {
+	prefix = unmapPrefix(prefix).Masked()
+	return s.containsRange(prefix.Addr(), lastAddr(prefix))
+}
+
+// Prefixes returns the addresses and networks the set was built from
+func (s IPSet) Prefixes() []netip.Prefix {
+	return s.prefixes
+}
+
+// Len returns the number of addresses and networks the set was built from
+func (s IPSet) Len() int {
+	return len(s.prefixes)
+}
+
+func (s IPSet) String() string {
+	elements := make([]string, len(s.prefixes))
+	for i, prefix := range s.prefixes {
+		if prefix.IsSingleIP() {
+			elements[i] = prefix.Addr().String()
+		} else {
+			elements[i] = prefix.String()
+		}
+	}
+	return strings.Join(elements, ",")
+}
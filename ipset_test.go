@@ -0,0 +1,125 @@
+package env
+
+import (
+	"net/netip"
+	"os"
+	"testing"
+)
+
+func TestIPSetParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{name: "addresses and networks", value: "10.0.0.0/8,192.168.1.1,2001:db8::/32", expected: "10.0.0.0/8,192.168.1.1,2001:db8::/32"},
+		{name: "spaces around elements", value: "10.0.0.0/8, ::1", expected: "10.0.0.0/8,::1"},
+		{name: "host bits are masked", value: "10.1.2.3/8", expected: "10.0.0.0/8"},
+		{name: "invalid address", value: "10.0.0.0/8,localhost", expectError: true},
+		{name: "invalid network", value: "10.0.0.0/33", expectError: true},
+		{name: "empty element", value: "10.0.0.1,", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ipSetParser(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got %v", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIPSetContains(t *testing.T) {
+	set, err := ipSetParser("10.0.0.0/8,10.255.255.255/32,11.0.0.0/8,192.168.1.1,2001:db8::/32,::1,0.0.0.0/1")
+	if err != nil {
+		t.Fatalf("Failed to parse set: %v", err)
+	}
+
+	tests := []struct {
+		addr     string
+		expected bool
+	}{
+		{"10.0.0.0", true},
+		{"10.255.255.255", true},
+		{"11.128.0.1", true},
+		{"12.0.0.1", true},
+		{"127.255.255.255", true},
+		{"128.0.0.0", false},
+		{"192.168.1.1", true},
+		{"192.168.1.2", false},
+		{"::ffff:10.1.1.1", true},
+		{"2001:db8::1", true},
+		{"2001:db8:ffff:ffff:ffff:ffff:ffff:ffff", true},
+		{"2001:db9::", false},
+		{"::1", true},
+		{"::2", false},
+		{"fe80::1%eth0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if result := set.Contains(netip.MustParseAddr(tt.addr)); result != tt.expected {
+				t.Errorf("Expected Contains(%s)=%v, got %v", tt.addr, tt.expected, result)
+			}
+		})
+	}
+
+	if len(set.ranges) != 4 {
+		t.Errorf("Expected adjacent and overlapping networks to be merged into 4 ranges, got %v", set.ranges)
+	}
+}
+
+func TestIPSetEmpty(t *testing.T) {
+	var set IPSet
+	if set.Contains(netip.MustParseAddr("10.0.0.1")) {
+		t.Errorf("Expected empty set to contain nothing")
+	}
+	if set.String() != "" || set.Len() != 0 {
+		t.Errorf("Expected empty set, got '%s'", set)
+	}
+}
+
+type TestConfigIPSet struct {
+	Allowed IPSet   `env:"required,name='IPSETALLOWED'"`
+	Zones   []IPSet `env:"optional,separator=';',name='IPSETZONES'"`
+	Trusted IPSet   `env:"optional,values='10.0.0.0/8',name='IPSETTRUSTED'"`
+}
+
+func TestAssertIPSet(t *testing.T) {
+	os.Setenv("IPSETALLOWED", "10.0.0.0/8,192.168.1.1")
+	os.Setenv("IPSETZONES", "10.0.0.0/8;2001:db8::/32,::1")
+	os.Setenv("IPSETTRUSTED", "10.1.0.0/16,10.2.0.1")
+	defer func() {
+		os.Unsetenv("IPSETALLOWED")
+		os.Unsetenv("IPSETZONES")
+		os.Unsetenv("IPSETTRUSTED")
+	}()
+
+	config, err := Assert(TestConfigIPSet{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if !config.Allowed.Contains(netip.MustParseAddr("10.1.2.3")) || config.Allowed.Contains(netip.MustParseAddr("192.168.1.2")) {
+		t.Errorf("Unexpected allowed set: %s", config.Allowed)
+	}
+	if len(config.Zones) != 2 || !config.Zones[1].Contains(netip.MustParseAddr("::1")) {
+		t.Errorf("Unexpected zones: %v", config.Zones)
+	}
+
+	os.Setenv("IPSETTRUSTED", "10.1.0.0/16,11.0.0.1")
+	if _, err := Assert(TestConfigIPSet{}); err == nil {
+		t.Errorf("Expected error for address outside the allowed networks")
+	}
+}
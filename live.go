@@ -0,0 +1,204 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Change describes a field whose value differs between two versions of a
+// configuration
+type Change struct {
+	Field  string // Path of the field, e.g. `Database.Host`
+	Name   string // Environment variable the field is read from
+	Old    any
+	New    any
+	Static bool // Whether the field is tagged `static`
+	Secret bool // Whether the field is tagged `secret`
+}
+
+// String describes the change, masking the values of secrets so that changes
+// can be logged
+func (c Change) String() string {
+	if c.Secret {
+		return fmt.Sprintf("%s (%s): %s -> %s", c.Field, c.Name, secretMask, secretMask)
+	}
+	return fmt.Sprintf("%s (%s): %v -> %v", c.Field, c.Name, c.Old, c.New)
+}
+
+// Diff returns the fields that changed between two versions of a config,
+// including the fields of nested structs
+func Diff[T any](old, new T) []Change {
+	return diffStruct(reflect.ValueOf(old), reflect.ValueOf(new), scope{}, false)
+}
+
+// Fields of a nested struct tagged `static` are static as well
+func diffStruct(old, new reflect.Value, s scope, static bool) []Change {
+	var changes []Change
+
+	for n := 0; n < old.NumField(); n++ {
+		field := old.Type().Field(n)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldStatic := static || isStatic(field.Tag.Get("env"))
+		if isNestedStruct(field.Type) {
+			changes = append(changes, diffStruct(old.Field(n), new.Field(n), s.nested(field), fieldStatic)...)
+			continue
+		}
+
+		oldValue := old.Field(n).Interface()
+		newValue := new.Field(n).Interface()
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, Change{
+				Field:  s.key(field),
+				Name:   s.envVarName(field),
+				Old:    oldValue,
+				New:    newValue,
+				Static: fieldStatic,
+				Secret: isSecret(field.Tag.Get("env")),
+			})
+		}
+	}
+
+	return changes
+}
+
+// Live holds the current version of a configuration that can change while
+// the program runs. Reading it is lock-free, so it can be used in hot paths.
+// The zero value is ready to use: Load returns the zero value of T until the
+// first Store, which sets the configuration like NewLive.
+type Live[T any] struct {
+	current atomic.Pointer[T]
+
+	// Serializes updates so that callbacks see versions in order
+	mu        sync.Mutex
+	callbacks []func(old, new T)
+}
+
+// NewLive returns a holder with the given configuration as current version
+func NewLive[T any](config T) *Live[T] {
+	l := &Live[T]{}
+	l.current.Store(&config)
+	return l
+}
+
+// Load returns the current version of the configuration
+func (l *Live[T]) Load() T {
+	current := l.current.Load()
+	if current == nil {
+		var zero T
+		return zero
+	}
+	return *current
+}
+
+// OnChange registers a function that is called with the previous and the new
+// version every time the configuration changes. Callbacks are called in the
+// order they were registered, from the goroutine that stored the change, and
+// must not call Store.
+func (l *Live[T]) OnChange(callback func(old, new T)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.callbacks = append(l.callbacks, callback)
+}
+
+// Store replaces the current version of the configuration and returns the
+// fields that changed. If any of them is tagged `static` the configuration is
+// not replaced and an error is returned instead.
+func (l *Live[T]) Store(config T) ([]Change, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The first version of a zero Live, there is nothing to compare it with
+	if l.current.Load() == nil {
+		l.current.Store(&config)
+		return nil, nil
+	}
+
+	old := l.Load()
+	changes := Diff(old, config)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	var static []string
+	for _, change := range changes {
+		if change.Static {
+			static = append(static, fmt.Sprintf("%s (%s)", change.Field, change.Name))
+		}
+	}
+	if len(static) > 0 {
+		return changes, fmt.Errorf("static fields cannot change at runtime: %s", strings.Join(static, ", "))
+	}
+
+	l.current.Store(&config)
+	for _, callback := range l.callbacks {
+		callback(old, config)
+	}
+
+	return changes, nil
+}
+
+// WatchLive watches the configuration like Watch and keeps a Live holder up
+// to date. It blocks until the configuration is first validated, returning
+// the error if that fails. Later validation errors, and attempts to change
+// static fields, are sent on the error channel, which must be drained and is
+// closed once the context is done.
+func WatchLive[T any](ctx context.Context, config T, opts WatchOptions) (*Live[T], <-chan error, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	configs, watchErrors := Watch(ctx, config, opts)
+
+	var live *Live[T]
+	select {
+	case initial, ok := <-configs:
+		if !ok {
+			cancel()
+			return nil, nil, ctx.Err()
+		}
+		live = NewLive(initial)
+	case err := <-watchErrors:
+		cancel()
+		return nil, nil, err
+	case <-ctx.Done():
+		cancel()
+		return nil, nil, ctx.Err()
+	}
+
+	errors := make(chan error)
+	go func() {
+		defer cancel()
+		defer close(errors)
+
+		for {
+			var err error
+			select {
+			case next, ok := <-configs:
+				if !ok {
+					return
+				}
+				_, err = live.Store(next)
+			case watchErr, ok := <-watchErrors:
+				if !ok {
+					return
+				}
+				err = watchErr
+			}
+
+			if err == nil {
+				continue
+			}
+			select {
+			case errors <- err:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return live, errors, nil
+}
@@ -0,0 +1,218 @@
+package env
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type TestLiveLimits struct {
+	MaxConns int `env:"required"`
+}
+
+type TestLiveConfig struct {
+	Port     int    `env:"required,static"`
+	LogLevel string `env:"optional,default='info'"`
+	Limits   TestLiveLimits
+	Storage  TestLiveLimits `env:"static"`
+}
+
+func TestDiff(t *testing.T) {
+	old := TestLiveConfig{Port: 8080, LogLevel: "info", Limits: TestLiveLimits{10}}
+	new := TestLiveConfig{Port: 8080, LogLevel: "debug", Limits: TestLiveLimits{20}}
+
+	changes := Diff(old, new)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %v", len(changes), changes)
+	}
+
+	if changes[0].Field != "LogLevel" || changes[0].Old != "info" || changes[0].New != "debug" {
+		t.Errorf("Unexpected change: %v", changes[0])
+	}
+	if changes[1].Field != "Limits.MaxConns" || changes[1].Name != "LIMITS_MAXCONNS" {
+		t.Errorf("Unexpected change: %v", changes[1])
+	}
+	if changes[1].Static {
+		t.Errorf("Expected Limits.MaxConns not to be static")
+	}
+
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
+
+func TestDiffMasksSecrets(t *testing.T) {
+	type config struct {
+		DBPassword string `env:"required,secret"`
+		DBHost     string `env:"required"`
+	}
+
+	changes := Diff(config{"old-secret", "db1"}, config{"new-secret", "db2"})
+	if len(changes) != 2 || !changes[0].Secret || changes[1].Secret {
+		t.Fatalf("Unexpected changes: %+v", changes)
+	}
+	if s := changes[0].String(); s != "DBPassword (DBPASSWORD): "+secretMask+" -> "+secretMask {
+		t.Errorf("Expected the secret to be masked, got '%s'", s)
+	}
+	if s := changes[1].String(); s != "DBHost (DBHOST): db1 -> db2" {
+		t.Errorf("Unexpected change: '%s'", s)
+	}
+}
+
+func TestLiveStore(t *testing.T) {
+	live := NewLive(TestLiveConfig{Port: 8080, LogLevel: "info"})
+
+	var calls []string
+	live.OnChange(func(old, new TestLiveConfig) {
+		calls = append(calls, old.LogLevel+"->"+new.LogLevel)
+	})
+
+	changes, err := live.Store(TestLiveConfig{Port: 8080, LogLevel: "debug"})
+	if err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Errorf("Expected 1 change, got %v", changes)
+	}
+	if live.Load().LogLevel != "debug" {
+		t.Errorf("Expected 'debug', got '%s'", live.Load().LogLevel)
+	}
+
+	// Storing the same config is a no-op
+	if _, err := live.Store(TestLiveConfig{Port: 8080, LogLevel: "debug"}); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	if len(calls) != 1 || calls[0] != "info->debug" {
+		t.Errorf("Expected a single callback call, got %v", calls)
+	}
+}
+
+func TestLiveStoreStatic(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        TestLiveConfig
+		errorContains string
+	}{
+		{
+			name:          "static field",
+			config:        TestLiveConfig{Port: 9090, LogLevel: "info"},
+			errorContains: "Port (PORT)",
+		},
+		{
+			name:          "field of static nested struct",
+			config:        TestLiveConfig{Port: 8080, LogLevel: "info", Storage: TestLiveLimits{5}},
+			errorContains: "Storage.MaxConns (STORAGE_MAXCONNS)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			live := NewLive(TestLiveConfig{Port: 8080, LogLevel: "info"})
+			live.OnChange(func(old, new TestLiveConfig) {
+				t.Errorf("Expected no callback for a rejected change")
+			})
+
+			_, err := live.Store(tt.config)
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error to contain '%s', got: %v", tt.errorContains, err)
+			}
+			if live.Load().Port != 8080 || live.Load().Storage.MaxConns != 0 {
+				t.Errorf("Expected config not to change, got %+v", live.Load())
+			}
+		})
+	}
+}
+
+func TestLiveZeroValue(t *testing.T) {
+	var live Live[TestLiveConfig]
+	if config := live.Load(); config.Port != 0 || config.LogLevel != "" {
+		t.Errorf("Expected the zero config, got %+v", config)
+	}
+
+	// The first version may set static fields
+	changes, err := live.Store(TestLiveConfig{Port: 8080, LogLevel: "info"})
+	if err != nil || changes != nil {
+		t.Fatalf("Expected the first Store to succeed without changes, got %v, %v", changes, err)
+	}
+	if live.Load().Port != 8080 {
+		t.Errorf("Expected port 8080, got %d", live.Load().Port)
+	}
+
+	if _, err := live.Store(TestLiveConfig{Port: 9090, LogLevel: "info"}); err == nil {
+		t.Errorf("Expected later versions to be checked for static changes")
+	}
+}
+
+func TestLiveConcurrentLoad(t *testing.T) {
+	live := NewLive(TestLiveConfig{Port: 8080})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = live.Load().LogLevel
+			}
+		}()
+	}
+	for _, level := range []string{"debug", "info", "warn"} {
+		live.Store(TestLiveConfig{Port: 8080, LogLevel: level})
+	}
+	wg.Wait()
+}
+
+func TestWatchLive(t *testing.T) {
+	os.Setenv("PORT", "8080")
+	os.Setenv("LIMITS_MAXCONNS", "10")
+	os.Setenv("STORAGE_MAXCONNS", "1")
+	defer func() {
+		os.Unsetenv("PORT")
+		os.Unsetenv("LIMITS_MAXCONNS")
+		os.Unsetenv("STORAGE_MAXCONNS")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trigger := make(chan struct{})
+	live, errors, err := WatchLive(ctx, TestLiveConfig{}, WatchOptions{Interval: time.Hour, Trigger: trigger})
+	if err != nil {
+		t.Fatalf("WatchLive failed: %v", err)
+	}
+
+	changed := make(chan struct{}, 1)
+	live.OnChange(func(old, new TestLiveConfig) {
+		changed <- struct{}{}
+	})
+
+	os.Setenv("LIMITS_MAXCONNS", "20")
+	trigger <- struct{}{}
+	receive(t, changed)
+	if live.Load().Limits.MaxConns != 20 {
+		t.Errorf("Expected 20, got %d", live.Load().Limits.MaxConns)
+	}
+
+	os.Setenv("PORT", "9090")
+	trigger <- struct{}{}
+	if err := receive(t, errors); !strings.Contains(err.Error(), "Port (PORT)") {
+		t.Errorf("Expected static field error, got: %v", err)
+	}
+	if live.Load().Port != 8080 {
+		t.Errorf("Expected port to stay 8080, got %d", live.Load().Port)
+	}
+}
+
+func TestWatchLiveInitialError(t *testing.T) {
+	_, _, err := WatchLive(context.Background(), TestLiveConfig{}, WatchOptions{Interval: time.Hour})
+	if err == nil {
+		t.Errorf("Expected error but got none")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 )
 
 type envVarType struct {
@@ -17,114 +18,165 @@ type envVarType struct {
 }
 type envMapType map[string]envVarType
 type invalidType struct {
-	name  string
-	value string
+	name   string
+	value  string
+	reason string
 }
 
-var envMap envMapType
+func (i invalidType) String() string {
+	if i.reason == "" {
+		return fmt.Sprintf("{%s %s}", i.name, i.value)
+	}
+	return fmt.Sprintf("{%s %s (%s)}", i.name, i.value, i.reason)
+}
 
 // Assert validates environment variables and returns a populated struct instance
-func Assert[T any](config T) (T, error) {
-	missing, invalid := Validate(config)
+func Assert[T any](config T, opts ...Option) (T, error) {
+	result, _, err := assert(config, newOptions(opts))
+	return result, err
+}
 
-	var errors []string
-	if missing != nil {
-		errors = append(errors, fmt.Sprintf("Missing: %v", missing))
+// Validates and populates the config, also returning the source the values
+// were read from.
+func assert[T any](config T, o *options) (T, *source, error) {
+	var zero T
+	src, ok := newSource(o)
+	if ok != nil {
+		return zero, nil, ok
 	}
-	if invalid != nil {
-		errors = append(errors, fmt.Sprintf("Invalid: %v", invalid))
+
+	v := validate(config, src)
+	report := v.report()
+	if o.provenance != nil {
+		*o.provenance = v.provenance
 	}
 
-	if len(errors) > 0 {
-		var zero T
-		return zero, err.New(strings.Join(errors, "\n"))
+	if !report.Valid() && !o.alwaysValidate {
+		return zero, src, &ValidationError{*report}
 	}
 
 	// Create a new instance of the struct and populate it with parsed values
 	result := reflect.New(reflect.TypeOf(config)).Elem()
+	populate(result, scope{}, v.environment)
+
+	// Checks that span several fields are left to the struct itself
+	report.Failed = runValidators(result, "")
+
+	if !report.Valid() {
+		return zero, src, &ValidationError{*report}
+	}
+
+	return result.Interface().(T), src, nil
+}
 
+// Sets the fields of the struct to the values parsed during validation.
+// Nested structs are populated recursively.
+func populate(result reflect.Value, s scope, environment envMapType) {
 	for n := 0; n < result.NumField(); n++ {
 		field := result.Field(n)
-		fieldName := result.Type().Field(n).Name
-
-		// Get the parsed value from envMap
-		if envVar, ok := envMap[fieldName]; ok {
-			// Handle slice types specially
-			if envVar.Type == reflect.Slice {
-				sliceValue := envVar.Value
-				if sliceValue.Kind() == reflect.Slice {
-					// Convert []interface{} to the target slice type
-					result := reflect.MakeSlice(field.Type(), sliceValue.Len(), sliceValue.Cap())
-					for i := 0; i < sliceValue.Len(); i++ {
-						elem := sliceValue.Index(i)
-						if elem.CanInterface() {
-							// Convert the element to the correct type
-							elemValue := reflect.ValueOf(elem.Interface())
-							if elemValue.Type().ConvertibleTo(field.Type().Elem()) {
-								result.Index(i).Set(elemValue.Convert(field.Type().Elem()))
-							} else {
-								// If direct conversion fails, try to parse as string first
-								if elemValue.Type() == reflect.TypeOf("") {
-									// Element is a string, parse it
-									elemStr := elem.Interface().(string)
-									parsed, err := parseVariable(fieldName, field.Type().Elem().Name(), elemStr)
-									if err == nil {
-										parsedValue := reflect.ValueOf(parsed)
-										if parsedValue.Type().ConvertibleTo(field.Type().Elem()) {
-											result.Index(i).Set(parsedValue.Convert(field.Type().Elem()))
-										} else {
-											// For custom string types like IPv4, create from the parsed string
-											if field.Type().Elem().Kind() == reflect.String {
-												customType := reflect.New(field.Type().Elem()).Elem()
-												customType.SetString(parsed.(string))
-												result.Index(i).Set(customType)
-											} else {
-												result.Index(i).Set(parsedValue)
-											}
-										}
+		structField := result.Type().Field(n)
+
+		if isNestedStruct(structField.Type) {
+			populate(field, s.nested(structField), environment)
+			continue
+		}
+
+		// Get the parsed value from the environment map. Optional fields
+		// without a value are stored without one and keep their zero value.
+		if envVar, ok := environment[s.key(structField)]; ok && envVar.Value.IsValid() {
+			setField(field, structField.Name, envVar)
+		}
+	}
+}
+
+// Sets the field to the parsed value, converting it to the type of the field
+func setField(field reflect.Value, fieldName string, envVar envVarType) {
+	// Handle slice types specially
+	if envVar.Type == reflect.Slice {
+		sliceValue := envVar.Value
+		if sliceValue.Kind() == reflect.Slice {
+			// Convert []interface{} to the target slice type
+			result := reflect.MakeSlice(field.Type(), sliceValue.Len(), sliceValue.Cap())
+			for i := 0; i < sliceValue.Len(); i++ {
+				elem := sliceValue.Index(i)
+				if elem.CanInterface() {
+					// Convert the element to the correct type
+					elemValue := reflect.ValueOf(elem.Interface())
+					if elemValue.Type().ConvertibleTo(field.Type().Elem()) {
+						result.Index(i).Set(elemValue.Convert(field.Type().Elem()))
+					} else {
+						// If direct conversion fails, try to parse as string first
+						if elemValue.Type() == reflect.TypeOf("") {
+							// Element is a string, parse it
+							elemStr := elem.Interface().(string)
+							parsed, err := parseVariable(fieldName, field.Type().Elem().Name(), elemStr)
+							if err == nil {
+								parsedValue := reflect.ValueOf(parsed)
+								if parsedValue.Type().ConvertibleTo(field.Type().Elem()) {
+									result.Index(i).Set(parsedValue.Convert(field.Type().Elem()))
+								} else {
+									// For custom string types like IPv4, create from the parsed string
+									if field.Type().Elem().Kind() == reflect.String {
+										customType := reflect.New(field.Type().Elem()).Elem()
+										customType.SetString(parsed.(string))
+										result.Index(i).Set(customType)
 									} else {
-										result.Index(i).Set(elemValue)
+										result.Index(i).Set(parsedValue)
 									}
-								} else {
-									result.Index(i).Set(elemValue)
 								}
+							} else {
+								result.Index(i).Set(elemValue)
 							}
+						} else {
+							result.Index(i).Set(elemValue)
 						}
 					}
-					field.Set(result)
 				}
+			}
+			field.Set(result)
+		}
+	} else {
+		// For non-slice types, set the value directly
+		// But first check if we need to convert custom types
+		if envVar.Value.Type().ConvertibleTo(field.Type()) {
+			field.Set(envVar.Value.Convert(field.Type()))
+		} else {
+			// For custom string types like IPv4, create from the parsed string
+			if field.Type().Kind() == reflect.String && envVar.Value.Type() == reflect.TypeOf("") {
+				customType := reflect.New(field.Type()).Elem()
+				customType.SetString(envVar.Value.Interface().(string))
+				field.Set(customType)
 			} else {
-				// For non-slice types, set the value directly
-				// But first check if we need to convert custom types
-				if envVar.Value.Type().ConvertibleTo(field.Type()) {
-					field.Set(envVar.Value.Convert(field.Type()))
-				} else {
-					// For custom string types like IPv4, create from the parsed string
-					if field.Type().Kind() == reflect.String && envVar.Value.Type() == reflect.TypeOf("") {
-						customType := reflect.New(field.Type()).Elem()
-						customType.SetString(envVar.Value.Interface().(string))
-						field.Set(customType)
-					} else {
-						field.Set(envVar.Value)
-					}
-				}
+				field.Set(envVar.Value)
 			}
 		}
 	}
-
-	return result.Interface().(T), nil
 }
 
 // MustAssert validates environment variables and returns a populated struct instance
-// It panics if validation fails, making it convenient for the common use case
-func MustAssert[T any](config T) T {
-	result, err := Assert(config)
-	if err != nil {
-		panic(fmt.Sprintf("Configuration error: %v", err))
+// It panics if validation fails, making it convenient for the common use case.
+// The panic message lists one variable per line, see ValidationError.Render.
+func MustAssert[T any](config T, opts ...Option) T {
+	result, ok := Assert(config, opts...)
+	if ok != nil {
+		var validationErr *ValidationError
+		if err.As(ok, &validationErr) {
+			panic("Configuration error:\n" + validationErr.Render(isTerminal(os.Stderr)))
+		}
+		panic(fmt.Sprintf("Configuration error: %v", ok))
 	}
 	return result
 }
 
+// Checks if the value of a field of the given type is allowed. The allowed
+// values of network types may be networks, e.g. `values='10.0.0.0/8'`.
+func isAllowedFor(fieldType string, value string, allowedValues []string) bool {
+	if len(allowedValues) > 0 && isNetworkType(fieldType) {
+		return isWithinNetworks(value, allowedValues)
+	}
+	return isValueAllowed(value, allowedValues)
+}
+
 // Checks if a string value is in the allowed values list
 func isValueAllowed(value string, allowedValues []string) bool {
 	if len(allowedValues) == 0 {
@@ -153,12 +205,36 @@ func parseVariable(fieldName string, fieldType string, value string) (any, error
 		parsed, ok = stringParser(value)
 	case "ipv4":
 		parsed, ok = ipv4Parser(value)
+	case "ipv6":
+		parsed, ok = ipv6Parser(value)
+	case "ip":
+		parsed, ok = ipParser(value)
+	case "cidr":
+		parsed, ok = cidrParser(value)
+	case "ipset":
+		parsed, ok = ipSetParser(value)
 	case "int":
 		parsed, ok = intParser(value)
 	case "url":
 		parsed, ok = urlParser(value)
 	case "httpurl":
 		parsed, ok = httpURLParser(value)
+	case "hostport":
+		parsed, ok = hostPortParser(value)
+	case "path", "file", "dir":
+		parsed, ok = pathParser(value)
+	case "certificate":
+		parsed, ok = certificateParser(value)
+	case "privatekey":
+		parsed, ok = privateKeyParser(value)
+	case "tlsversion":
+		parsed, ok = tlsVersionParser(value)
+	case "dsn":
+		parsed, ok = dsnParser(value)
+	case "percent":
+		parsed, ok = percentParser(value)
+	case "rate":
+		parsed, ok = rateParser(value)
 	default:
 		panic(fmt.Sprintf(
 			"Unrecognized type '%s' for field '%s'", fieldType, fieldName))
@@ -179,83 +255,287 @@ func getEnvVarNameFromField(field reflect.StructField) string {
 	return name
 }
 
+// The position of a struct within the configuration. Fields are stored in the
+// environment map under their path, e.g. `Database.Host`, and are read from
+// environment variables with the prefix prepended, e.g. `DATABASE_HOST`.
+type scope struct {
+	path   string
+	prefix string
+}
+
+// Returns the key under which the field is stored in the environment map
+func (s scope) key(field reflect.StructField) string {
+	return s.path + field.Name
+}
+
+// Returns the name of the environment variable the field is read from
+func (s scope) envVarName(field reflect.StructField) string {
+	return strings.ToUpper(s.prefix + getEnvVarNameFromField(field))
+}
+
+// Returns the error reported for the field
+func (s scope) fieldError(field reflect.StructField, value string, reason string) FieldError {
+	tag := field.Tag.Get("env")
+	return FieldError{
+		Field:    s.key(field),
+		Variable: s.envVarName(field),
+		Type:     typeName(field.Type),
+		Value:    displayValue(field, value),
+		Reason:   reason,
+		Values:   allowedValues(field),
+		Secret:   isSecret(tag),
+	}
+}
+
+// Returns the value as shown in reports. Passwords of DSNs are redacted, even
+// when the field is not secret.
+func displayValue(field reflect.StructField, value string) string {
+	if !isDSNType(field.Type) {
+		return value
+	}
+	if field.Type.Kind() != reflect.Slice {
+		return redactDSN(value)
+	}
+
+	sep := getSeparator(field.Tag.Get("env"))
+	elements := strings.Split(value, sep)
+	for i, element := range elements {
+		elements[i] = redactDSN(element)
+	}
+	return strings.Join(elements, sep)
+}
+
+// Returns the scope of a nested struct. Unless a prefix is given in the tag,
+// the fields of the nested struct are prefixed with the name of the field,
+// e.g. `Database.Host` is read from `DATABASE_HOST`.
+func (s scope) nested(field reflect.StructField) scope {
+	prefix, ok := getPrefix(field.Tag.Get("env"))
+	if !ok {
+		prefix = field.Name + "_"
+	}
+
+	return scope{
+		path:   s.key(field) + ".",
+		prefix: s.prefix + prefix,
+	}
+}
+
+// Structs are validated field by field as part of the parent configuration,
+// they are not parsed from a single environment variable. The types of the
+// library that are structs, like CIDR, are parsed from a single variable.
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !structTypes[t]
+}
+
+// The types of the library that are structs but are read from a single
+// environment variable
+var structTypes = map[reflect.Type]bool{
+	reflect.TypeOf(CIDR{}):        true,
+	reflect.TypeOf(IPSet{}):       true,
+	reflect.TypeOf(HostPort{}):    true,
+	reflect.TypeOf(Certificate{}): true,
+	reflect.TypeOf(PrivateKey{}):  true,
+	reflect.TypeOf(DSN{}):         true,
+	reflect.TypeOf(Rate{}):        true,
+}
+
+// The result of validating a configuration
+type validation struct {
+	source      *source
+	environment envMapType
+	missing     []FieldError
+	invalid     []FieldError
+
+	// Where the value of each field came from, in the order of the fields
+	provenance Provenance
+}
+
+func (v *validation) report() *Report {
+	return &Report{Missing: v.missing, Invalid: v.invalid}
+}
+
 // Validates the environment variables and returns a list of missing and invalid
-// variables. If the value is valid, it will be added to the environment map.
+// variables.
 func Validate(variables interface{}) ([]string, []invalidType) {
-	environment := make(envMapType)
+	return validate(variables, osSource()).report().legacy()
+}
+
+func validate(variables interface{}, src *source) *validation {
 	t := reflect.TypeOf(variables)
 	if t.Kind() != reflect.Struct {
 		panic("Invalid parameter")
 	}
 
-	var missing []string
-	var invalid []invalidType
+	v := &validation{source: src, environment: make(envMapType)}
+	if strictErr := src.resolveStrict(); strictErr != nil {
+		v.invalid = append(v.invalid, *strictErr)
+	}
+	v.validateStruct(t, scope{})
+
+	return v
+}
+
+// Validates the fields of the struct. If the value is valid, it will be added
+// to the environment map.
+func (v *validation) validateStruct(t reflect.Type, s scope) {
+	// The value of each field after applying defaults, needed to evaluate the
+	// conditional options once all the fields have been read.
+	resolved := make(map[string]string)
 
 	for n := 0; n < t.NumField(); n++ {
 		field := t.Field(n)
-		name := strings.ToUpper(getEnvVarNameFromField(field))
-		value := os.Getenv(name)
-		optional := isOptional(field.Tag.Get("env"))
+		if isNestedStruct(field.Type) {
+			v.validateStruct(field.Type, s.nested(field))
+			continue
+		}
+
+		name := s.envVarName(field)
+		value, from, readErr := v.source.lookup(name)
+		from.Field, from.Variable, from.Secret = s.key(field), name, isSecret(field.Tag.Get("env"))
+		v.provenance = append(v.provenance, from)
+		origin := &v.provenance[len(v.provenance)-1]
+		if readErr != nil {
+			v.invalid = append(v.invalid, s.fieldError(field, "", readErr.Error()))
+			continue
+		}
+		optional := isOptional(field.Tag.Get("env")) || isConditional(field.Tag.Get("env"))
+		value = transformValue(field, value)
 
 		if value == "" {
-			if optional {
-				// If the field is optional, we can use the default value if it exists
-				if hasDefault(field.Tag.Get("env")) {
-					value = getDefault(field.Tag.Get("env"))
-
-					// Validate that the default value is in allowed values if values are specified
-					if hasValues(field.Tag.Get("env")) {
-						allowedValues := getValues(field.Tag.Get("env"))
-						if !isValueAllowed(value, allowedValues) {
-							panic(fmt.Sprintf("Default value '%s' for field '%s' is not in allowed values: %v", value, field.Name, allowedValues))
-						}
+			tag := field.Tag.Get("env")
+			defaultValue, found := v.source.defaultFor(tag, optional)
+
+			switch {
+			case found && v.source.defaultForbidden(tag) != "":
+				// Production must not run with a value nobody set on purpose
+				v.missing = append(v.missing, s.fieldError(field, "", v.source.defaultForbidden(tag)))
+				continue
+			case isOptional(tag) && getExclusive(tag) == "" && v.source.strictFor(tag):
+				// Fields of an exclusive group are meant to be left unset
+				// but for one, so strict mode doesn't require them
+				v.missing = append(v.missing, s.fieldError(field, "", "optional fields must be set in strict mode"))
+				continue
+			case found:
+				value = transformValue(field, defaultValue)
+				origin.Source, origin.Default = SourceDefault, true
+
+				// Validate that the default value is in allowed values if values are specified,
+				// or is one of the values of an enum. The values of slices apply to each element.
+				if allowed := allowedValues(field); allowed != nil {
+					defaults, defaultType := []string{value}, field.Type.Name()
+					if field.Type.Kind() == reflect.Slice {
+						defaults, _ = splitSlice(value, getSliceOptions(tag))
+						transformElements(field, defaults)
+						defaultType = elementTypeName(field.Type)
 					}
-				} else {
-					// If the field is optional and has no default value, we can use a zero value
-					environment[field.Name] = envVarType{
-						reflect.Value{},
-						field.Type.Kind(),
+					for _, d := range defaults {
+						if !isAllowedFor(defaultType, d, allowed) {
+							panic(fmt.Sprintf("Default value '%s' for field '%s' is not in allowed values: %v", d, field.Name, allowed))
+						}
 					}
-
-					// We can continue to the next field, nothing to validate
-					continue
 				}
-			} else {
+			case optional:
+				// If the field is optional and has no default value, we can use a zero value
+				v.environment[s.key(field)] = envVarType{
+					reflect.Value{},
+					field.Type.Kind(),
+				}
+
+				// We can continue to the next field, nothing to validate
+				continue
+			default:
 				// If the field is required and has no value, we add it to the missing list
-				missing = append(missing, fmt.Sprintf("%s (%s)", field.Name, name))
+				v.missing = append(v.missing, s.fieldError(field, "", ""))
 
 				// We can continue to the next field, nothing to validate
 				continue
 			}
 		}
+		resolved[field.Name] = value
+		origin.Value = displayValue(field, value)
 
 		var ok error
 		var parsed any
 		kind := field.Type.Kind().String()
 		if kind == "slice" {
-			sep := getSeparator(field.Tag.Get("env"))
-			// Get the element type from the slice
-			elementType := field.Type.Elem()
-			elementTypeName := elementType.Name()
-			if elementTypeName == "" {
-				elementTypeName = elementType.String()
+			o := getSliceOptions(field.Tag.Get("env"))
+			elements, splitErr := splitSlice(value, o)
+			transformElements(field, elements)
+			if port, ok := defaultPortFor(field); ok {
+				for i, element := range elements {
+					elements[i] = withDefaultPort(element, port)
+				}
+			}
+			if o, ok := pathOptionsFor(field); ok && splitErr == nil {
+				o.dryRun = v.source.dryRun
+				splitErr = resolvePaths(elements, elementTypeName(field.Type), o)
+			}
+			if splitErr == nil && len(o.values) > 0 {
+				splitErr = checkAllowedElements(elementTypeName(field.Type), elements, o.values)
+			}
+			if splitErr != nil {
+				v.invalid = append(v.invalid, s.fieldError(field, value, splitErr.Error()))
+				continue
 			}
-			parsed, ok = validateAndParseSlice(field.Name, elementTypeName, value, sep)
+
+			var values []any
+			values, ok = parseElements(elements, v.parserFor(field))
+			if ok == nil && o.unique {
+				ok = checkUniqueElements(values)
+			}
+			if ok == nil {
+				for i, value := range values {
+					if err := checkParsed(field, value); err != nil {
+						ok = fmt.Errorf("element %d: %v", i, err)
+						break
+					}
+				}
+			}
+			if ok != nil {
+				v.invalid = append(v.invalid, s.fieldError(field, value, ok.Error()))
+				continue
+			}
+			parsed = values
 		} else {
+			if port, ok := defaultPortFor(field); ok {
+				value = withDefaultPort(value, port)
+			}
+			if o, ok := pathOptionsFor(field); ok {
+				o.dryRun = v.source.dryRun
+				var pathErr error
+				if value, pathErr = resolvePath(value, field.Type.Name(), o); pathErr != nil {
+					v.invalid = append(v.invalid, s.fieldError(field, value, pathErr.Error()))
+					continue
+				}
+			}
+
 			// Check if the value is in the allowed values before parsing
 			if hasValues(field.Tag.Get("env")) {
 				allowedValues := getValues(field.Tag.Get("env"))
-				if !isValueAllowed(value, allowedValues) {
-					invalid = append(invalid, invalidType{fmt.Sprintf("%s (%s)", field.Name, name), value})
+				if !isAllowedFor(field.Type.Name(), value, allowedValues) {
+					v.invalid = append(v.invalid, s.fieldError(field, value, ""))
 					continue
 				}
 			}
 
-			parsed, ok = parseVariable(field.Name, field.Type.Name(), value)
+			parsed, ok = v.parserFor(field)(value)
+			if ok == nil {
+				if err := checkParsed(field, parsed); err != nil {
+					v.invalid = append(v.invalid, s.fieldError(field, value, err.Error()))
+					continue
+				}
+			}
 		}
 
 		if ok != nil {
-			invalid = append(invalid, invalidType{fmt.Sprintf("%s (%s)", field.Name, name), value})
+			// Parse errors may have the value, they are only shown when they
+			// explain what was expected
+			var expected expectedError
+			reason := ""
+			if err.As(ok, &expected) {
+				reason = expected.Error()
+			}
+			v.invalid = append(v.invalid, s.fieldError(field, value, reason))
 		} else {
 			var varType reflect.Kind
 			if kind == "slice" {
@@ -263,31 +543,130 @@ func Validate(variables interface{}) ([]string, []invalidType) {
 			} else {
 				varType = field.Type.Kind()
 			}
-			environment[field.Name] = envVarType{
+			v.environment[s.key(field)] = envVarType{
 				reflect.ValueOf(parsed),
 				varType,
 			}
 		}
 	}
 
-	envMap = environment
-	return missing, invalid
+	if t == reflect.TypeOf(TLS{}) {
+		if versionErr := checkTLSVersion(s, resolved["MinVersion"]); versionErr != nil {
+			v.invalid = append(v.invalid, *versionErr)
+		}
+		if keyErr := checkTLSKey(s, v.environment); keyErr != nil {
+			v.invalid = append(v.invalid, *keyErr)
+		}
+	}
+
+	conditionalMissing, conditionalInvalid := validateConditions(t, s, resolved, v.environment, v.parserFor)
+	v.missing = append(v.missing, conditionalMissing...)
+	v.invalid = append(v.invalid, conditionalInvalid...)
 }
 
-// Given a slice field and its corresponding environment variable value, it will
-// parse the value into the correct type and return a slice of the parsed values.
-// If the value is invalid, it will return an error.
-func validateAndParseSlice(fieldName string, fieldType string, value string, sep string) ([]any, error) {
+// Returns the name of the type of the elements of a slice, e.g. `IPv4`
+func elementTypeName(t reflect.Type) string {
+	elementType := t.Elem()
+	if elementType.Name() == "" {
+		return elementType.String()
+	}
+	return elementType.Name()
+}
+
+// Checks what can only be checked once the value is parsed, like the expiry
+// of a certificate. Unlike parse errors, the error is reported as the reason
+// the value is invalid.
+func checkParsed(field reflect.StructField, parsed any) error {
+	names := certificateNamesFor(field)
+	drivers := dsnDriversFor(field)
+
+	switch value := parsed.(type) {
+	case Certificate:
+		return checkCertificate(value, names, time.Now())
+	case DSN:
+		return checkDSNDriver(value, drivers)
+	}
+	if b, ok := boundsFor(field); ok {
+		return b.check(parsed)
+	}
+	return nil
+}
+
+// Parses the elements of a slice with the parser of the field, see parserFor.
+// The error lists the elements that are not valid and, if the parser explains
+// it, what was expected.
+func parseElements(elements []string, parse func(string) (any, error)) ([]any, error) {
 	var values []any
-	var allOk = true
-	for slice := range strings.SplitSeq(value, sep) {
-		parsed, ok := parseVariable(fieldName, fieldType, slice)
+	var failed []int
+	var expected expectedError
+	for i, element := range elements {
+		parsed, ok := parse(element)
 		values = append(values, parsed)
-		allOk = allOk && ok == nil
+		if ok != nil {
+			failed = append(failed, i)
+			err.As(ok, &expected)
+		}
+	}
+
+	if len(failed) == 0 {
+		return values, nil
+	}
+	if expected.expected != "" {
+		return values, fmt.Errorf("invalid slice: %s not valid, %s", describeElements(failed), expected)
+	}
+	return values, fmt.Errorf("invalid slice: %s not valid", describeElements(failed))
+}
+
+// Returns the parser of the value of the field, or of each element of a slice.
+// Enums, booleans, ints with units and the files of certificates depend on
+// more than the name of the type.
+func (v *validation) parserFor(field reflect.StructField) func(string) (any, error) {
+	if e, ok := enumForField(field); ok {
+		return e.parse
 	}
 
-	if !allOk {
-		return values, fmt.Errorf("invalid slice: %v", allOk)
+	tag := field.Tag.Get("env")
+	fieldType := field.Type.Name()
+	if field.Type.Kind() == reflect.Slice {
+		fieldType = elementTypeName(field.Type)
+	}
+
+	if strings.EqualFold(fieldType, "bool") {
+		vocabulary := v.source.boolVocabularyFor(tag)
+		return func(value string) (any, error) {
+			return vocabulary.parse(value)
+		}
 	}
-	return values, nil
+	if _, ok := getBoolVocabulary(tag); ok {
+		panic(fmt.Sprintf("Option 'bool' of field '%s' is only valid for boolean fields", field.Name))
+	}
+	if unitsFor(field) {
+		return func(value string) (any, error) {
+			return humanIntParser(value)
+		}
+	}
+	if isPEMType(field.Type) {
+		// Certificates are rotated by replacing their files, which must be
+		// watched like the other files the values are read from
+		return func(value string) (any, error) {
+			if !isInlinePEM(value) {
+				v.source.files = append(v.source.files, value)
+			}
+			return parseVariable(field.Name, fieldType, value)
+		}
+	}
+
+	return func(value string) (any, error) {
+		return parseVariable(field.Name, fieldType, value)
+	}
+}
+
+// A parse error that explains what was expected without repeating the value,
+// so it can be shown as the reason the value is invalid
+type expectedError struct {
+	expected string
+}
+
+func (e expectedError) Error() string {
+	return e.expected
 }
@@ -195,7 +195,7 @@ func TestAssert(t *testing.T) {
 	}
 }
 
-func TestValidateAndParseSlice(t *testing.T) {
+func TestParseElements(t *testing.T) {
 	tests := []struct {
 		name        string
 		fieldName   string
@@ -248,7 +248,7 @@ func TestValidateAndParseSlice(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := validateAndParseSlice(tt.fieldName, tt.fieldType, tt.value, tt.sep)
+			result, err := parseElements(strings.Split(tt.value, tt.sep), sliceParser(tt.fieldName, tt.fieldType))
 
 			if tt.expectError && err == nil {
 				t.Errorf("Expected error but got none")
@@ -0,0 +1,135 @@
+package env
+
+import (
+	"os"
+	"testing"
+)
+
+// Test structs for nested configuration
+type TestNestedDatabase struct {
+	Host string `env:"required"`
+	Port int    `env:"optional,default='5432'"`
+}
+
+type TestNestedConfig struct {
+	Database TestNestedDatabase
+	Replica  TestNestedDatabase `env:"prefix='RO_'"`
+	Cache    TestNestedDatabase `env:"prefix=''"`
+	AppName  string             `env:"required"`
+}
+
+func TestNestedStructValidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		envVars         map[string]string
+		expectedMissing []string
+		expectedInvalid []string
+	}{
+		{
+			name: "all nested fields set",
+			envVars: map[string]string{
+				"DATABASE_HOST": "db.example.com",
+				"RO_HOST":       "replica.example.com",
+				"HOST":          "cache.example.com",
+				"APPNAME":       "myapp",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{},
+		},
+		{
+			name: "missing nested fields",
+			envVars: map[string]string{
+				"APPNAME": "myapp",
+			},
+			expectedMissing: []string{"Database.Host (DATABASE_HOST)", "Replica.Host (RO_HOST)", "Cache.Host (HOST)"},
+			expectedInvalid: []string{},
+		},
+		{
+			name: "invalid nested field",
+			envVars: map[string]string{
+				"DATABASE_HOST": "db.example.com",
+				"DATABASE_PORT": "not-a-number",
+				"RO_HOST":       "replica.example.com",
+				"HOST":          "cache.example.com",
+				"APPNAME":       "myapp",
+			},
+			expectedMissing: []string{},
+			expectedInvalid: []string{"Database.Port (DATABASE_PORT)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.envVars {
+					os.Unsetenv(key)
+				}
+			}()
+
+			missing, invalid := Validate(TestNestedConfig{})
+
+			if len(missing) != len(tt.expectedMissing) {
+				t.Errorf("Expected %d missing fields, got %d: %v", len(tt.expectedMissing), len(missing), missing)
+			}
+			for i, expected := range tt.expectedMissing {
+				if i < len(missing) && missing[i] != expected {
+					t.Errorf("Expected missing field '%s', got '%s'", expected, missing[i])
+				}
+			}
+
+			if len(invalid) != len(tt.expectedInvalid) {
+				t.Errorf("Expected %d invalid fields, got %d: %v", len(tt.expectedInvalid), len(invalid), invalid)
+			}
+			for i, expected := range tt.expectedInvalid {
+				if i < len(invalid) && invalid[i].name != expected {
+					t.Errorf("Expected invalid field '%s', got '%s'", expected, invalid[i].name)
+				}
+			}
+		})
+	}
+}
+
+func TestNestedStructAssert(t *testing.T) {
+	envVars := map[string]string{
+		"DATABASE_HOST": "db.example.com",
+		"DATABASE_PORT": "6432",
+		"RO_HOST":       "replica.example.com",
+		"HOST":          "cache.example.com",
+		"APPNAME":       "myapp",
+	}
+	for key, value := range envVars {
+		os.Setenv(key, value)
+	}
+	defer func() {
+		for key := range envVars {
+			os.Unsetenv(key)
+		}
+	}()
+
+	config, err := Assert(TestNestedConfig{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.Database.Host != "db.example.com" {
+		t.Errorf("Expected 'db.example.com', got '%s'", config.Database.Host)
+	}
+	if config.Database.Port != 6432 {
+		t.Errorf("Expected 6432, got %d", config.Database.Port)
+	}
+	if config.Replica.Host != "replica.example.com" {
+		t.Errorf("Expected 'replica.example.com', got '%s'", config.Replica.Host)
+	}
+	if config.Replica.Port != 5432 {
+		t.Errorf("Expected 5432, got %d", config.Replica.Port)
+	}
+	if config.Cache.Host != "cache.example.com" {
+		t.Errorf("Expected 'cache.example.com', got '%s'", config.Cache.Host)
+	}
+	if config.AppName != "myapp" {
+		t.Errorf("Expected 'myapp', got '%s'", config.AppName)
+	}
+}
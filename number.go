@@ -0,0 +1,190 @@
+package env
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Numbers written for humans: digits grouped with underscores, an optional
+// fraction and exponent, and an SI suffix, e.g. `10_000`, `1.5k` or `1e6`.
+// Exponents are limited to two digits so that a value can't make the parser
+// allocate huge numbers.
+const unsignedNumberPattern = `[0-9]+(_[0-9]+)*(\.[0-9]+(_[0-9]+)*)?([eE][+-]?[0-9]{1,2})?[kKMG]?`
+
+const humanNumberPattern = `[+-]?` + unsignedNumberPattern
+
+var humanNumberRegex = regexp.MustCompile(`^` + humanNumberPattern + `$`)
+
+// The multipliers of the SI suffixes. `m` is milli, not mega, so it is not
+// accepted to avoid any confusion.
+var siSuffixes = map[byte]int64{
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+}
+
+// Parses a number written for humans into an exact rational, so that large
+// integers don't lose precision
+func parseHumanNumber(value string) (*big.Rat, error) {
+	if !humanNumberRegex.MatchString(value) {
+		return nil, expectedError{"expected a number like 10_000, 1.5k or 1e6"}
+	}
+
+	multiplier := int64(1)
+	if m, ok := siSuffixes[value[len(value)-1]]; ok {
+		multiplier = m
+		value = value[:len(value)-1]
+	}
+
+	number, ok := new(big.Rat).SetString(strings.ReplaceAll(value, "_", ""))
+	if !ok {
+		return nil, fmt.Errorf("invalid number: %s", value)
+	}
+	return number.Mul(number, big.NewRat(multiplier, 1)), nil
+}
+
+// Type: int with the `units` option. The value may use the syntax of
+// parseHumanNumber as long as it is a whole number, e.g. `1.5k` but not `1.5`.
+func humanIntParser(value string) (int, error) {
+	number, err := parseHumanNumber(value)
+	if err != nil {
+		return 0, err
+	}
+	if !number.IsInt() {
+		return 0, expectedError{"expected a whole number"}
+	}
+
+	n := number.Num()
+	if !n.IsInt64() || n.Int64() > math.MaxInt || n.Int64() < math.MinInt {
+		return 0, expectedError{"number out of range"}
+	}
+	return int(n.Int64()), nil
+}
+
+// Returns whether the int field, or the ints of a slice, accept the syntax
+// of humanIntParser. The option is only valid on ints.
+func unitsFor(field reflect.StructField) bool {
+	if !hasUnits(field.Tag.Get("env")) {
+		return false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if _, ok := enumFor(t); ok || !strings.EqualFold(t.Name(), "int") {
+		panic(fmt.Sprintf("Option 'units' of field '%s' is only valid for int fields", field.Name))
+	}
+	return true
+}
+
+// The range of a numeric field, set with the `min` and `max` options. The
+// bounds are kept as written in the tag to show them in messages.
+type bounds struct {
+	min, max           string
+	minValue, maxValue *big.Rat
+}
+
+// Returns the bounds of the field and whether it has any. Bounds are written
+// like the values of the field, e.g. `min='50%'` or `max='100/s'`, and ints
+// always accept the syntax of humanIntParser in them. Bounds on other types,
+// or that are not valid, are programming errors.
+func boundsFor(field reflect.StructField) (bounds, bool) {
+	low, high := getMin(field.Tag.Get("env")), getMax(field.Tag.Get("env"))
+	if low == "" && high == "" {
+		return bounds{}, false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	parse, ok := numberParser(t)
+	if !ok {
+		panic(fmt.Sprintf("Options 'min' and 'max' of field '%s' are only valid for int, Percent and Rate fields", field.Name))
+	}
+
+	b, err := parseBounds(low, high, parse)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid bounds of field '%s': %v", field.Name, err))
+	}
+	return b, true
+}
+
+// Parses the bounds with the parser of the type, either may be empty
+func parseBounds(low string, high string, parse func(string) (any, error)) (bounds, error) {
+	b := bounds{min: low, max: high}
+	if low != "" {
+		parsed, err := parse(low)
+		if err != nil {
+			return b, fmt.Errorf("min '%s': %v", low, err)
+		}
+		b.minValue = magnitude(parsed)
+	}
+	if high != "" {
+		parsed, err := parse(high)
+		if err != nil {
+			return b, fmt.Errorf("max '%s': %v", high, err)
+		}
+		b.maxValue = magnitude(parsed)
+	}
+
+	if b.minValue != nil && b.maxValue != nil && b.minValue.Cmp(b.maxValue) > 0 {
+		return b, fmt.Errorf("min '%s' is greater than max '%s'", low, high)
+	}
+	return b, nil
+}
+
+// Checks that the parsed value is within the bounds
+func (b bounds) check(parsed any) error {
+	value := magnitude(parsed)
+	if b.minValue != nil && value.Cmp(b.minValue) < 0 {
+		return fmt.Errorf("must be at least %s", b.min)
+	}
+	if b.maxValue != nil && value.Cmp(b.maxValue) > 0 {
+		return fmt.Errorf("must be at most %s", b.max)
+	}
+	return nil
+}
+
+// Returns the parser of the numeric types that can have bounds. Enums are not
+// numbers even when their constants are ints.
+func numberParser(t reflect.Type) (func(string) (any, error), bool) {
+	if _, ok := enumFor(t); ok {
+		return nil, false
+	}
+
+	return numberParserFor(t.Name())
+}
+
+// Returns the parser of the numeric type with the given name, e.g. `Percent`
+func numberParserFor(typeName string) (func(string) (any, error), bool) {
+	switch strings.ToLower(typeName) {
+	case "int":
+		return func(value string) (any, error) { return humanIntParser(value) }, true
+	case "percent":
+		return func(value string) (any, error) { return percentParser(value) }, true
+	case "rate":
+		return func(value string) (any, error) { return rateParser(value) }, true
+	}
+	return nil, false
+}
+
+// Returns the value of a parsed number to compare it with the bounds. Rates
+// are compared by their events per second.
+func magnitude(parsed any) *big.Rat {
+	switch value := reflect.ValueOf(parsed); {
+	case value.Type() == reflect.TypeOf(Rate{}):
+		return new(big.Rat).SetFloat64(parsed.(Rate).perSecond())
+	case value.CanInt():
+		return new(big.Rat).SetInt64(value.Int())
+	case value.CanFloat():
+		return new(big.Rat).SetFloat64(value.Float())
+	}
+	panic(fmt.Sprintf("Value of type %T is not a number", parsed))
+}
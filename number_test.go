@@ -0,0 +1,293 @@
+package env
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHumanIntParser(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    int
+		expectError bool
+	}{
+		{value: "42", expected: 42},
+		{value: "-42", expected: -42},
+		{value: "+42", expected: 42},
+		{value: "10_000", expected: 10000},
+		{value: "1_000_000", expected: 1000000},
+		{value: "10k", expected: 10000},
+		{value: "10K", expected: 10000},
+		{value: "1.5k", expected: 1500},
+		{value: "5M", expected: 5000000},
+		{value: "2G", expected: 2000000000},
+		{value: "1e6", expected: 1000000},
+		{value: "2.5E3", expected: 2500},
+		{value: "150e-1", expected: 15},
+		{value: "-1k", expected: -1000},
+		{value: "9223372036854775807", expected: 9223372036854775807},
+		{value: "1.5", expectError: true},
+		{value: "1.0001k", expectError: true},
+		{value: "10m", expectError: true},
+		{value: "10T", expectError: true},
+		{value: "_10", expectError: true},
+		{value: "10_", expectError: true},
+		{value: "1__0", expectError: true},
+		{value: "1e100", expectError: true},
+		{value: "1e999", expectError: true},
+		{value: "9223372036854775808", expectError: true},
+		{value: "0x10", expectError: true},
+		{value: "1/2", expectError: true},
+		{value: "10 k", expectError: true},
+		{value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := humanIntParser(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got %d", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigNumbers struct {
+	Workers   int     `env:"required,units,min='1',max='1k',name='NUMWORKERS'"`
+	Port      int     `env:"optional,min='1',max='65535',name='NUMPORT'"`
+	Sizes     []int   `env:"optional,units,separator=',',min='0',name='NUMSIZES'"`
+	Threshold Percent `env:"optional,min='50%',max='100%',name='NUMTHRESHOLD'"`
+	Limit     Rate    `env:"optional,max='1k/s',name='NUMLIMIT'"`
+}
+
+func TestAssertNumbers(t *testing.T) {
+	t.Setenv("NUMWORKERS", "1_000")
+	t.Setenv("NUMPORT", "8080")
+	t.Setenv("NUMSIZES", "1k, 2.5M,0")
+	t.Setenv("NUMTHRESHOLD", "75%")
+	t.Setenv("NUMLIMIT", "6k/min")
+
+	config, err := Assert(TestConfigNumbers{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.Workers != 1000 || config.Port != 8080 {
+		t.Errorf("Unexpected ints: %+v", config)
+	}
+	if len(config.Sizes) != 3 || config.Sizes[0] != 1000 || config.Sizes[1] != 2500000 {
+		t.Errorf("Unexpected sizes: %v", config.Sizes)
+	}
+	if config.Threshold != 0.75 {
+		t.Errorf("Expected 0.75, got %v", float64(config.Threshold))
+	}
+	if config.Limit.Per(time.Second) != 100 {
+		t.Errorf("Expected 100 events per second, got %v", config.Limit.Per(time.Second))
+	}
+}
+
+func TestValidateNumbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		invalid string
+		reason  string
+	}{
+		{
+			name:    "below min",
+			envVars: map[string]string{"NUMWORKERS": "0"},
+			invalid: "Workers (NUMWORKERS)",
+			reason:  "must be at least 1",
+		},
+		{
+			name:    "above max written with a suffix",
+			envVars: map[string]string{"NUMWORKERS": "1.5k"},
+			invalid: "Workers (NUMWORKERS)",
+			reason:  "must be at most 1k",
+		},
+		{
+			name:    "units are opt-in",
+			envVars: map[string]string{"NUMWORKERS": "1", "NUMPORT": "8_080"},
+			invalid: "Port (NUMPORT)",
+		},
+		{
+			name:    "invalid human number",
+			envVars: map[string]string{"NUMWORKERS": "1.5"},
+			invalid: "Workers (NUMWORKERS)",
+			reason:  "expected a whole number",
+		},
+		{
+			name:    "slice element below min",
+			envVars: map[string]string{"NUMWORKERS": "1", "NUMSIZES": "1k,-1"},
+			invalid: "Sizes (NUMSIZES)",
+			reason:  "element 1: must be at least 0",
+		},
+		{
+			name:    "percent below min",
+			envVars: map[string]string{"NUMWORKERS": "1", "NUMTHRESHOLD": "25%"},
+			invalid: "Threshold (NUMTHRESHOLD)",
+			reason:  "must be at least 50%",
+		},
+		{
+			name:    "percent without the percent sign",
+			envVars: map[string]string{"NUMWORKERS": "1", "NUMTHRESHOLD": "75"},
+			invalid: "Threshold (NUMTHRESHOLD)",
+			reason:  "expected a percentage like 75%",
+		},
+		{
+			name:    "rate above max in another unit",
+			envVars: map[string]string{"NUMWORKERS": "1", "NUMLIMIT": "100k/min"},
+			invalid: "Limit (NUMLIMIT)",
+			reason:  "must be at most 1k/s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigNumbers{}, tt.envVars)
+			if len(report.Invalid) != 1 || report.Invalid[0].label() != tt.invalid {
+				t.Fatalf("Expected %s to be invalid, got %+v", tt.invalid, report.Invalid)
+			}
+			if report.Invalid[0].Reason != tt.reason {
+				t.Errorf("Expected reason '%s', got '%s'", tt.reason, report.Invalid[0].Reason)
+			}
+		})
+	}
+}
+
+func TestDescribeNumbers(t *testing.T) {
+	description := Describe(TestConfigNumbers{})
+	if v := description[0]; !v.Units || v.Min != "1" || v.Max != "1k" {
+		t.Errorf("Unexpected description of NUMWORKERS: %+v", v)
+	}
+	var example strings.Builder
+	if err := WriteExample(&example, TestConfigNumbers{}); err != nil {
+		t.Fatalf("WriteExample failed: %v", err)
+	}
+	if !strings.Contains(example.String(), "# int, required, min 1, max 1k\nNUMWORKERS=") {
+		t.Errorf("Expected the bounds in the example, got:\n%s", example.String())
+	}
+
+	data, err := JSONSchema(TestConfigNumbers{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Invalid schema: %v", err)
+	}
+	if p := schema.Properties["NUMTHRESHOLD"]; p.Minimum != "50%" || p.Maximum != "100%" {
+		t.Errorf("Expected the bounds in the schema, got %+v", p)
+	}
+
+	patterns := []struct {
+		variable string
+		value    string
+		expected bool
+	}{
+		{"NUMWORKERS", "10_000", true},
+		{"NUMWORKERS", "1.5k", true},
+		{"NUMWORKERS", "10m", false},
+		{"NUMPORT", "8_080", false},
+		{"NUMSIZES", "1k,2M", true},
+		{"NUMTHRESHOLD", "75%", true},
+		{"NUMTHRESHOLD", "0.75", false},
+		{"NUMTHRESHOLD", "75", false},
+		{"NUMTHRESHOLD", "75 %", false},
+		{"NUMLIMIT", "100/s", true},
+		{"NUMLIMIT", "5k/min", true},
+		{"NUMLIMIT", "10/1m30s", true},
+		{"NUMLIMIT", "100/fortnight", false},
+	}
+	for _, tt := range patterns {
+		pattern := schema.Properties[tt.variable].Pattern
+		if matched := regexp.MustCompile(pattern).MatchString(tt.value); matched != tt.expected {
+			t.Errorf("Expected %v for %s=%s with pattern '%s'", tt.expected, tt.variable, tt.value, pattern)
+		}
+	}
+}
+
+func TestSchemaCheckBounds(t *testing.T) {
+	schema := NewSchema(TestConfigNumbers{})
+
+	tests := []struct {
+		name      string
+		variables map[string]string
+		reason    string
+	}{
+		{name: "int above max", variables: map[string]string{"NUMWORKERS": "2k", "NUMTHRESHOLD": "50%"}, reason: "must be at most 1k"},
+		{name: "percent below min", variables: map[string]string{"NUMWORKERS": "1", "NUMTHRESHOLD": "10%"}, reason: "must be at least 50%"},
+		{name: "rate above max", variables: map[string]string{"NUMWORKERS": "1", "NUMLIMIT": "2k/s"}, reason: "must be at most 1k/s"},
+		{name: "valid", variables: map[string]string{"NUMWORKERS": "1k", "NUMPORT": "80", "NUMLIMIT": "60k/min"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := schema.Check(tt.variables)
+			if tt.reason == "" {
+				if len(report.Invalid) != 0 {
+					t.Errorf("Expected no invalid variables, got %+v", report.Invalid)
+				}
+				return
+			}
+			if len(report.Invalid) != 1 || report.Invalid[0].Reason != tt.reason {
+				t.Errorf("Expected reason '%s', got %+v", tt.reason, report.Invalid)
+			}
+		})
+	}
+}
+
+func TestNumberOptionPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+	}{
+		{
+			name: "units on a string",
+			config: struct {
+				Size string `env:"required,units,name='NUMVALUE'"`
+			}{},
+		},
+		{
+			name: "min on a string",
+			config: struct {
+				Size string `env:"required,min='1',name='NUMVALUE'"`
+			}{},
+		},
+		{
+			name: "invalid bound",
+			config: struct {
+				Size int `env:"required,max='lots',name='NUMVALUE'"`
+			}{},
+		},
+		{
+			name: "min greater than max",
+			config: struct {
+				Size int `env:"required,min='10k',max='1k',name='NUMVALUE'"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic")
+				}
+			}()
+			ValidateMap(tt.config, map[string]string{"NUMVALUE": "5"})
+		})
+	}
+}
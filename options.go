@@ -0,0 +1,139 @@
+package env
+
+import (
+	"io"
+	"os"
+)
+
+// Option configures the behaviour of Assert, MustAssert and MustAssertOrExit
+type Option func(*options)
+
+type options struct {
+	alwaysValidate bool
+	dotenv         []string
+	fileSecrets    bool
+	provenance     *Provenance
+	profile        string
+	profileVar     string
+	strict         bool
+	bools          BoolVocabulary
+
+	// Used by MustAssertOrExit
+	exitCode    int
+	exit        func(int)
+	errorOutput io.Writer
+	beforeExit  []func()
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		profileVar:  defaultProfileVar,
+		exitCode:    ExitConfig,
+		exit:        os.Exit,
+		errorOutput: os.Stderr,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAlwaysValidate runs the Validate method of the config structs even when
+// some fields are missing or invalid. The struct is populated with the fields
+// that are valid, the rest keep their zero value.
+func WithAlwaysValidate() Option {
+	return func(o *options) {
+		o.alwaysValidate = true
+	}
+}
+
+// WithDotenv reads the variables that are not set in the environment from the
+// given dotenv files. When several files define a variable, the last one wins.
+func WithDotenv(paths ...string) Option {
+	return func(o *options) {
+		o.dotenv = append(o.dotenv, paths...)
+	}
+}
+
+// WithFileSecrets reads the value of a variable that is not set from the file
+// in the variable with the `_FILE` suffix, e.g. `DB_PASSWORD` is read from the
+// file in `DB_PASSWORD_FILE`. Trailing newlines are removed.
+func WithFileSecrets() Option {
+	return func(o *options) {
+		o.fileSecrets = true
+	}
+}
+
+// WithProfile selects the defaults of the given profile, e.g. `dev` selects
+// `default.dev='...'`. Under the `prod` profile defaults are not allowed.
+func WithProfile(profile string) Option {
+	return func(o *options) {
+		o.profile = profile
+	}
+}
+
+// WithProfileVar sets the variable the profile is read from when it is not
+// given with WithProfile, `APP_ENV` by default
+func WithProfileVar(name string) Option {
+	return func(o *options) {
+		o.profileVar = name
+	}
+}
+
+// WithStrict rejects every field that would take its value from a default,
+// and every optional field that is not set, unless the field opts out with
+// `nostrict`. Strict mode is also enabled by setting `ENV_STRICT=1`.
+func WithStrict() Option {
+	return func(o *options) {
+		o.strict = true
+	}
+}
+
+// WithBoolVocabulary sets the words accepted as booleans, BoolStandard by
+// default. Fields may use their own with the `bool` tag option, e.g.
+// `bool='extended'`.
+func WithBoolVocabulary(vocabulary BoolVocabulary) Option {
+	vocabulary.words() // Unknown vocabularies panic
+	return func(o *options) {
+		o.bools = vocabulary
+	}
+}
+
+// WithProvenance stores where the value of every field came from in p, see
+// Explain. It is set even if the configuration is not valid.
+func WithProvenance(p *Provenance) Option {
+	return func(o *options) {
+		o.provenance = p
+	}
+}
+
+// WithExitCode sets the code MustAssertOrExit exits with, 78 by default
+func WithExitCode(code int) Option {
+	return func(o *options) {
+		o.exitCode = code
+	}
+}
+
+// WithExitFunc replaces os.Exit in MustAssertOrExit, e.g. to test it
+func WithExitFunc(exit func(int)) Option {
+	return func(o *options) {
+		o.exit = exit
+	}
+}
+
+// WithErrorOutput sets where MustAssertOrExit writes the report, os.Stderr by
+// default
+func WithErrorOutput(w io.Writer) Option {
+	return func(o *options) {
+		o.errorOutput = w
+	}
+}
+
+// WithBeforeExit registers a function that MustAssertOrExit calls after writing
+// the report and before exiting, e.g. to flush logs. Functions are called in
+// the order they were registered.
+func WithBeforeExit(fn func()) Option {
+	return func(o *options) {
+		o.beforeExit = append(o.beforeExit, fn)
+	}
+}
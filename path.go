@@ -0,0 +1,217 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Type: Path, File and Dir. Their values are only checked for syntax when
+// parsed, the options of the field decide what is checked on disk, see
+// resolvePath.
+func pathParser(value string) (string, error) {
+	if value == "" || strings.ContainsRune(value, 0) {
+		return "", fmt.Errorf("invalid path: %q", value)
+	}
+	return value, nil
+}
+
+// Path is a path to a file or a directory
+type Path string
+
+// File is a path to a file. If it exists it must not be a directory.
+type File string
+
+// Dir is a path to a directory. If it exists it must be a directory.
+type Dir string
+
+// The checks of the path types, set with tag options like `exists`
+type pathOptions struct {
+	exists     bool
+	readable   bool
+	writable   bool
+	executable bool
+	create     bool
+	abs        bool
+
+	// Not an option of the tag: directories are not created and writability
+	// is not probed, see source.dryRun
+	dryRun bool
+}
+
+func (o pathOptions) any() bool {
+	return o != pathOptions{}
+}
+
+// Returns the path options of the field and whether it is a path type. The
+// options are only valid on path types, using them anywhere else is a
+// programming error.
+func pathOptionsFor(field reflect.StructField) (pathOptions, bool) {
+	o := getPathOptions(field.Tag.Get("env"))
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	switch t {
+	case reflect.TypeOf(Path("")), reflect.TypeOf(File("")), reflect.TypeOf(Dir("")):
+		return o, true
+	}
+
+	if o.any() {
+		panic(fmt.Sprintf("Path options of field '%s' are only valid for Path, File and Dir fields", field.Name))
+	}
+	return o, false
+}
+
+// Resolves the path and checks it on disk according to the options. The type
+// is the name of the path type, e.g. `Dir`. Directories are created before
+// checking them, so `create` and `writable` can be used together.
+func resolvePath(value string, fieldType string, o pathOptions) (string, error) {
+	isDir := strings.EqualFold(fieldType, "dir")
+	isFile := strings.EqualFold(fieldType, "file")
+
+	if o.abs {
+		abs, err := absPath(value)
+		if err != nil {
+			return value, err
+		}
+		value = abs
+	}
+
+	if o.create {
+		dir := value
+		if !isDir {
+			// Files are created by the application, only their directory
+			// is created here
+			dir = filepath.Dir(value)
+		}
+		if o.dryRun {
+			// Whatever would be created can't be checked yet
+			if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+				return value, nil
+			}
+		} else if err := os.MkdirAll(dir, 0o755); err != nil {
+			return value, fmt.Errorf("can't create directory: %v", err)
+		}
+	}
+
+	info, err := os.Stat(value)
+	if errors.Is(err, fs.ErrNotExist) {
+		// A file that doesn't exist yet can be written if its directory can
+		if o.writable && !o.exists && !o.readable && !o.executable {
+			if o.dryRun {
+				return value, nil
+			}
+			return value, checkWritableDir(filepath.Dir(value))
+		}
+		if o.exists || o.readable || o.executable || o.writable {
+			return value, fmt.Errorf("%s does not exist", value)
+		}
+		return value, nil
+	}
+	if err != nil {
+		return value, fmt.Errorf("can't access %s: %v", value, err)
+	}
+
+	switch {
+	case isDir && !info.IsDir():
+		return value, fmt.Errorf("%s is not a directory", value)
+	case isFile && info.IsDir():
+		return value, fmt.Errorf("%s is a directory", value)
+	}
+
+	if o.readable {
+		if err := checkReadable(value, info); err != nil {
+			return value, err
+		}
+	}
+	if o.writable && !o.dryRun {
+		if err := checkWritable(value, info); err != nil {
+			return value, err
+		}
+	}
+	if o.executable && info.Mode().Perm()&0o111 == 0 {
+		return value, fmt.Errorf("%s is not executable", value)
+	}
+
+	return value, nil
+}
+
+// Resolves and checks the elements of a slice in place
+func resolvePaths(elements []string, fieldType string, o pathOptions) error {
+	for i, element := range elements {
+		if element == "" {
+			continue
+		}
+		resolved, err := resolvePath(element, fieldType, o)
+		if err != nil {
+			return fmt.Errorf("element %d: %v", i, err)
+		}
+		elements[i] = resolved
+	}
+	return nil
+}
+
+// Returns the absolute path, expanding a leading `~` to the home directory
+func absPath(value string) (string, error) {
+	if value == "~" || strings.HasPrefix(value, "~/") || strings.HasPrefix(value, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("can't expand ~: %v", err)
+		}
+		value = filepath.Join(home, value[1:])
+	}
+
+	abs, err := filepath.Abs(value)
+	if err != nil {
+		return "", fmt.Errorf("can't resolve %s: %v", value, err)
+	}
+	return abs, nil
+}
+
+// Files are checked by opening them, and directories by listing them
+func checkReadable(path string, info fs.FileInfo) error {
+	f, err := os.Open(path)
+	if err == nil && info.IsDir() {
+		_, err = f.ReadDir(1)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if f != nil {
+		f.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("%s is not readable", path)
+	}
+	return nil
+}
+
+// Files are checked by opening them for writing, without truncating them, and
+// directories by creating a temporary file in them
+func checkWritable(path string, info fs.FileInfo) error {
+	if info.IsDir() {
+		return checkWritableDir(path)
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("%s is not writable", path)
+	}
+	return f.Close()
+}
+
+func checkWritableDir(dir string) error {
+	f, err := os.CreateTemp(dir, ".env-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable", dir)
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,257 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cert.pem")
+	script := filepath.Join(dir, "hook.sh")
+	if err := os.WriteFile(file, []byte("cert"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte("#!/bin/sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		name          string
+		value         string
+		fieldType     string
+		options       pathOptions
+		errorContains string
+	}{
+		{name: "missing path without checks", value: missing, fieldType: "Path"},
+		{name: "missing path that must exist", value: missing, fieldType: "Path", options: pathOptions{exists: true}, errorContains: "does not exist"},
+		{name: "existing file", value: file, fieldType: "File", options: pathOptions{exists: true}},
+		{name: "directory as a file", value: dir, fieldType: "File", errorContains: "is a directory"},
+		{name: "file as a directory", value: file, fieldType: "Dir", errorContains: "is not a directory"},
+		{name: "existing directory", value: dir, fieldType: "Dir", options: pathOptions{exists: true, readable: true, writable: true}},
+		{name: "readable file", value: file, fieldType: "File", options: pathOptions{readable: true}},
+		{name: "readable missing file", value: missing, fieldType: "File", options: pathOptions{readable: true}, errorContains: "does not exist"},
+		{name: "writable file", value: file, fieldType: "File", options: pathOptions{writable: true}},
+		{name: "writable missing file in a writable directory", value: missing, fieldType: "File", options: pathOptions{writable: true}},
+		{name: "writable missing file that must exist", value: missing, fieldType: "File", options: pathOptions{writable: true, exists: true}, errorContains: "does not exist"},
+		{name: "executable file", value: script, fieldType: "File", options: pathOptions{executable: true}},
+		{name: "file that is not executable", value: file, fieldType: "File", options: pathOptions{executable: true}, errorContains: "is not executable"},
+		{name: "created directory", value: filepath.Join(dir, "data", "cache"), fieldType: "Dir", options: pathOptions{create: true, exists: true, writable: true}},
+		{name: "directory of a created file", value: filepath.Join(dir, "logs", "app.log"), fieldType: "File", options: pathOptions{create: true, writable: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolvePath(tt.value, tt.fieldType, tt.options)
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing '%s', got %v", tt.errorContains, err)
+			}
+		})
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "logs")); err != nil {
+		t.Errorf("Expected the directory of the file to be created: %v", err)
+	}
+}
+
+func TestResolvePathPermissions(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced for root")
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret")
+	if err := os.WriteFile(file, []byte("secret"), 0o200); err != nil {
+		t.Fatal(err)
+	}
+	readOnly := filepath.Join(dir, "readonly")
+	if err := os.Mkdir(readOnly, 0o500); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := resolvePath(file, "File", pathOptions{readable: true}); err == nil || !strings.Contains(err.Error(), "is not readable") {
+		t.Errorf("Expected the file not to be readable, got %v", err)
+	}
+	if _, err := resolvePath(readOnly, "Dir", pathOptions{writable: true}); err == nil || !strings.Contains(err.Error(), "is not writable") {
+		t.Errorf("Expected the directory not to be writable, got %v", err)
+	}
+}
+
+func TestAbsPath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"~", home},
+		{"~/certs", filepath.Join(home, "certs")},
+		{"certs", filepath.Join(wd, "certs")},
+		{"/etc/../etc/ssl", "/etc/ssl"},
+		{"~user/certs", filepath.Join(wd, "~user", "certs")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := absPath(tt.value)
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigPath struct {
+	Certs   Dir    `env:"required,exists,readable,name='PATHCERTS'"`
+	Data    Dir    `env:"required,create,writable,name='PATHDATA'"`
+	Config  File   `env:"optional,exists,abs,name='PATHCONFIG'"`
+	Plugins []Dir  `env:"optional,separator=',',exists,name='PATHPLUGINS'"`
+	Socket  Path   `env:"optional,name='PATHSOCKET'"`
+	Hooks   []File `env:"optional,separator=',',executable,name='PATHHOOKS'"`
+}
+
+func TestAssertPaths(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(config, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	envVars := map[string]string{
+		"PATHCERTS":   dir,
+		"PATHDATA":    filepath.Join(dir, "data"),
+		"PATHCONFIG":  "config.yaml",
+		"PATHPLUGINS": dir + "," + filepath.Join(dir, "data"),
+		"PATHSOCKET":  "/run/app.sock",
+	}
+	for key, value := range envVars {
+		t.Setenv(key, value)
+	}
+
+	result, err := Assert(TestConfigPath{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if result.Config != File(config) {
+		t.Errorf("Expected the absolute path '%s', got '%s'", config, result.Config)
+	}
+	if info, err := os.Stat(string(result.Data)); err != nil || !info.IsDir() {
+		t.Errorf("Expected the data directory to be created: %v", err)
+	}
+	if len(result.Plugins) != 2 || result.Socket != "/run/app.sock" {
+		t.Errorf("Unexpected paths: %+v", result)
+	}
+}
+
+func TestValidatePaths(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, []byte{}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		envVars       map[string]string
+		invalid       string
+		errorContains string
+	}{
+		{
+			name:          "missing certificate directory",
+			envVars:       map[string]string{"PATHCERTS": filepath.Join(dir, "certs"), "PATHDATA": dir},
+			invalid:       "Certs (PATHCERTS)",
+			errorContains: "does not exist",
+		},
+		{
+			name:          "file instead of a directory",
+			envVars:       map[string]string{"PATHCERTS": file, "PATHDATA": dir},
+			invalid:       "Certs (PATHCERTS)",
+			errorContains: "is not a directory",
+		},
+		{
+			name:          "missing slice element",
+			envVars:       map[string]string{"PATHCERTS": dir, "PATHDATA": dir, "PATHPLUGINS": dir + "," + filepath.Join(dir, "plugins")},
+			invalid:       "Plugins (PATHPLUGINS)",
+			errorContains: "element 1",
+		},
+		{
+			name:          "hook that is not executable",
+			envVars:       map[string]string{"PATHCERTS": dir, "PATHDATA": dir, "PATHHOOKS": file},
+			invalid:       "Hooks (PATHHOOKS)",
+			errorContains: "is not executable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigPath{}, tt.envVars)
+			if len(report.Invalid) != 1 || report.Invalid[0].label() != tt.invalid {
+				t.Fatalf("Expected %s to be invalid, got %+v", tt.invalid, report.Invalid)
+			}
+			if !strings.Contains(report.Invalid[0].Reason, tt.errorContains) {
+				t.Errorf("Expected reason containing '%s', got '%s'", tt.errorContains, report.Invalid[0].Reason)
+			}
+		})
+	}
+}
+
+func TestValidateMapLeavesFilesystem(t *testing.T) {
+	dir := t.TempDir()
+	data := filepath.Join(dir, "data")
+	log := filepath.Join(dir, "logs", "app.log")
+	config := struct {
+		Data Dir  `env:"required,create,writable,name='PATHDATA'"`
+		Log  File `env:"required,create,writable,name='PATHLOG'"`
+		Tmp  Dir  `env:"required,writable,name='PATHTMP'"`
+	}{}
+	variables := map[string]string{"PATHDATA": data, "PATHLOG": log, "PATHTMP": dir}
+
+	if report := ValidateMap(config, variables); !report.Valid() {
+		t.Fatalf("Expected the paths to be valid, got:\n%s", report.Render(false))
+	}
+	for k, v := range variables {
+		t.Setenv(k, v)
+	}
+	Explain(config)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected nothing to be created, found %v", entries)
+	}
+}
+
+func TestPathOptionsPanicOnOtherTypes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic")
+		}
+	}()
+
+	ValidateMap(struct {
+		Name string `env:"required,exists,name='PATHNAME'"`
+	}{}, map[string]string{"PATHNAME": "value"})
+}
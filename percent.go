@@ -0,0 +1,36 @@
+package env
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const percentPattern = `[+-]?([0-9]+(\.[0-9]+)?|\.[0-9]+)%`
+
+var percentRegex = regexp.MustCompile(`^` + percentPattern + `$`)
+
+// Type: Percent. Values are divided by 100, e.g. `75%` is 0.75. The percent
+// sign is required: `75` could be meant as 75% or as 7500%, and `0.75` as 75%
+// or as 0.75%.
+func percentParser(value string) (float64, error) {
+	if !percentRegex.MatchString(value) {
+		return 0, expectedError{"expected a percentage like 75%"}
+	}
+
+	parsed, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, err
+	}
+	return parsed / 100, nil
+}
+
+// Percent is a fraction read from a percentage, e.g. `75%` is 0.75
+type Percent float64
+
+// String returns the percentage, e.g. `75%`
+func (p Percent) String() string {
+	// Rounded to hide the error of the floating point division, e.g. 7% is
+	// not exactly 0.07
+	return strconv.FormatFloat(float64(p)*100, 'g', 12, 64) + "%"
+}
@@ -0,0 +1,70 @@
+package env
+
+import (
+	"testing"
+)
+
+func TestPercentParser(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    float64
+		expectError bool
+	}{
+		{value: "75%", expected: 0.75},
+		{value: "12.5%", expected: 0.125},
+		{value: "150%", expected: 1.5},
+		{value: "0%", expected: 0},
+		{value: "-10%", expected: -0.1},
+		{value: ".5%", expected: 0.005},
+		{value: "0.75", expectError: true},
+		{value: "75", expectError: true},
+		{value: "1", expectError: true},
+		{value: "75 %", expectError: true},
+		{value: "%", expectError: true},
+		{value: "75%%", expectError: true},
+		{value: "1e2%", expectError: true},
+		{value: "NaN", expectError: true},
+		{value: "Inf", expectError: true},
+		{value: "seventy", expectError: true},
+		{value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := percentParser(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for value '%s' but got %v", tt.value, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPercentString(t *testing.T) {
+	tests := []struct {
+		value    Percent
+		expected string
+	}{
+		{0.75, "75%"},
+		{0.07, "7%"},
+		{0.125, "12.5%"},
+		{1.5, "150%"},
+		{0, "0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := tt.value.String(); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The profile under which defaults are not allowed, so that production never
+// runs with a value nobody set on purpose
+const ProfileProduction = "prod"
+
+// The variable the profile is read from unless WithProfileVar is given
+const defaultProfileVar = "APP_ENV"
+
+// Returns the active profile in lowercase. The profile given with WithProfile
+// takes precedence over the profile variable.
+func (s *source) activeProfile() string {
+	if !s.profileResolved {
+		if s.profile == "" {
+			// An unreadable profile is the same as no profile, the variable
+			// is reported by its own field if the configuration reads it
+			s.profile, _, _ = s.lookup(s.profileVar)
+		}
+		s.profile = strings.ToLower(strings.TrimSpace(s.profile))
+		s.profileResolved = true
+	}
+	return s.profile
+}
+
+// Returns the default value of the field for the active profile. A default for
+// the profile, e.g. `default.dev='localhost'`, takes precedence over the
+// `default` option and applies even if the field is not optional, so that a
+// field can be required everywhere but in development. An empty default for
+// the profile means the field has no default under it.
+func (s *source) defaultFor(tag string, optional bool) (string, bool) {
+	if profiles := getProfileDefaults(tag); profiles != nil {
+		if value, ok := profiles[s.activeProfile()]; ok {
+			return value, value != ""
+		}
+	}
+
+	if optional && hasDefault(tag) {
+		return getDefault(tag), true
+	}
+	return "", false
+}
+
+// Returns why the field can't take its default, or an empty string if it can.
+// Defaults are not allowed under the `prod` profile, nor in strict mode unless
+// the field opts out with `nostrict`.
+func (s *source) defaultForbidden(tag string) string {
+	if profile := s.activeProfile(); profile == ProfileProduction {
+		return fmt.Sprintf("defaults are not allowed in profile '%s'", profile)
+	}
+	if s.strictFor(tag) {
+		return "defaults are not allowed in strict mode"
+	}
+	return ""
+}
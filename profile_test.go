@@ -0,0 +1,158 @@
+package env
+
+import (
+	"os"
+	"testing"
+)
+
+type TestConfigProfile struct {
+	Host    string `env:"default.dev='localhost',default.test='127.0.0.1',name='PROFHOST'"`
+	Workers int    `env:"optional,default='4',default.dev='1',name='PROFWORKERS'"`
+	Token   string `env:"optional,default='dev-token',default.staging='',name='PROFTOKEN'"`
+}
+
+func TestProfileDefaults(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []Option
+		envVars         map[string]string
+		expected        TestConfigProfile
+		expectedMissing []string
+	}{
+		{
+			name:     "profile from option",
+			opts:     []Option{WithProfile("dev")},
+			expected: TestConfigProfile{Host: "localhost", Workers: 1, Token: "dev-token"},
+		},
+		{
+			name:     "profile from APP_ENV",
+			envVars:  map[string]string{"APP_ENV": "test"},
+			expected: TestConfigProfile{Host: "127.0.0.1", Workers: 4, Token: "dev-token"},
+		},
+		{
+			name:     "profile is case insensitive",
+			envVars:  map[string]string{"APP_ENV": "DEV"},
+			expected: TestConfigProfile{Host: "localhost", Workers: 1, Token: "dev-token"},
+		},
+		{
+			name:     "profile from custom variable",
+			opts:     []Option{WithProfileVar("PROFILE")},
+			envVars:  map[string]string{"PROFILE": "dev", "APP_ENV": "test"},
+			expected: TestConfigProfile{Host: "localhost", Workers: 1, Token: "dev-token"},
+		},
+		{
+			name:     "option takes precedence over variable",
+			opts:     []Option{WithProfile("dev")},
+			envVars:  map[string]string{"APP_ENV": "test"},
+			expected: TestConfigProfile{Host: "localhost", Workers: 1, Token: "dev-token"},
+		},
+		{
+			name:     "empty profile default disables the default",
+			opts:     []Option{WithProfile("staging")},
+			envVars:  map[string]string{"PROFHOST": "db"},
+			expected: TestConfigProfile{Host: "db", Workers: 4, Token: ""},
+		},
+		{
+			name:            "field without default for the profile is required",
+			envVars:         map[string]string{},
+			expectedMissing: []string{"Host (PROFHOST)"},
+		},
+		{
+			name:     "values set are used in any profile",
+			opts:     []Option{WithProfile("dev")},
+			envVars:  map[string]string{"PROFHOST": "db", "PROFWORKERS": "8"},
+			expected: TestConfigProfile{Host: "db", Workers: 8, Token: "dev-token"},
+		},
+		{
+			name:    "prod forbids defaults",
+			opts:    []Option{WithProfile("prod")},
+			envVars: map[string]string{"PROFHOST": "db"},
+			expectedMissing: []string{
+				"Workers (PROFWORKERS): defaults are not allowed in profile 'prod'",
+				"Token (PROFTOKEN): defaults are not allowed in profile 'prod'",
+			},
+		},
+		{
+			name:     "prod with every value set",
+			envVars:  map[string]string{"APP_ENV": "prod", "PROFHOST": "db", "PROFWORKERS": "16", "PROFTOKEN": "t"},
+			expected: TestConfigProfile{Host: "db", Workers: 16, Token: "t"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.envVars {
+					os.Unsetenv(key)
+				}
+			}()
+
+			config, err := Assert(TestConfigProfile{}, tt.opts...)
+
+			if tt.expectedMissing != nil {
+				if err == nil {
+					t.Fatalf("Expected error but got none")
+				}
+				missing, _ := err.(*ValidationError).legacy()
+				if len(missing) != len(tt.expectedMissing) {
+					t.Fatalf("Expected missing %v, got %v", tt.expectedMissing, missing)
+				}
+				for i, expected := range tt.expectedMissing {
+					if missing[i] != expected {
+						t.Errorf("Expected missing '%s', got '%s'", expected, missing[i])
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Assert failed: %v", err)
+			}
+			if config != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, config)
+			}
+		})
+	}
+}
+
+func TestProfileDefaultNotInValues(t *testing.T) {
+	type config struct {
+		Mode string `env:"optional,values='a,b',default.dev='c',name='PROFMODE'"`
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for profile default not in allowed values")
+		}
+	}()
+
+	Assert(config{}, WithProfile("dev"))
+}
+
+func TestGetProfileDefaults(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected map[string]string
+	}{
+		{tag: "optional,default='x'", expected: nil},
+		{tag: "default.dev='localhost'", expected: map[string]string{"dev": "localhost"}},
+		{tag: "default.Dev='a',default.prod=''", expected: map[string]string{"dev": "a", "prod": ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			result := getProfileDefaults(tt.tag)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, result)
+			}
+			for profile, value := range tt.expected {
+				if result[profile] != value {
+					t.Errorf("Expected %s='%s', got '%s'", profile, value, result[profile])
+				}
+			}
+		})
+	}
+}
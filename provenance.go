@@ -0,0 +1,77 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// The sources a value can come from
+const (
+	SourceEnvironment = "environment" // The environment of the process
+	SourceFile        = "file"        // The file in the variable with the `_FILE` suffix
+	SourceDotenv      = "dotenv"      // A dotenv file given with WithDotenv
+	SourceDefault     = "default"     // The default value in the tag
+	SourceUnset       = "unset"       // Not set anywhere, the field has its zero value
+)
+
+// Origin describes where the value of a field came from
+type Origin struct {
+	Field    string // Path of the field, e.g. `Database.Host`
+	Variable string // Name of the environment variable, e.g. `DATABASE_HOST`
+	Source   string // One of the Source constants
+	Path     string // The file the value was read from, if any
+	Value    string // The raw value, before parsing
+	Default  bool   // Whether the default value was used
+	Secret   bool   // Whether the value must not be shown
+}
+
+// Provenance lists the origin of every field of a configuration, in the order
+// of the fields
+type Provenance []Origin
+
+// Returns the source with the file it was read from, e.g. `dotenv (.env)`
+func (o Origin) source() string {
+	if o.Path == "" {
+		return o.Source
+	}
+	return fmt.Sprintf("%s (%s)", o.Source, o.Path)
+}
+
+// Returns the value to show, masked for secrets
+func (o Origin) value() string {
+	switch {
+	case o.Source == SourceUnset && o.Value == "":
+		return "-"
+	case o.Secret:
+		return secretMask
+	default:
+		return fmt.Sprintf("%q", o.Value)
+	}
+}
+
+// String returns a table with the field, variable, source and value of every
+// field. Values of secrets are masked.
+func (p Provenance) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tVARIABLE\tSOURCE\tVALUE")
+	for _, o := range p {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", o.Field, o.Variable, o.source(), o.value())
+	}
+	w.Flush()
+	return b.String()
+}
+
+// Explain returns a table with where the value of every field of the config
+// struct comes from, taking the options into account. It is meant to debug
+// which of the environment, a secret file, a dotenv file or a default wins.
+// Values of secrets are masked.
+func Explain(config any, opts ...Option) string {
+	src, err := newSource(newOptions(opts))
+	if err != nil {
+		return fmt.Sprintf("Configuration error: %v\n", err)
+	}
+	src.dryRun = true
+	return validate(config, src).provenance.String()
+}
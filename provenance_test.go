@@ -0,0 +1,106 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type TestConfigProvenance struct {
+	Host     string `env:"required,name='PROVHOST'"`
+	Port     int    `env:"required,name='PROVPORT'"`
+	Password string `env:"required,secret,name='PROVPASSWORD'"`
+	Mode     string `env:"optional,default='remote',name='PROVMODE'"`
+	Token    string `env:"optional,name='PROVTOKEN'"`
+}
+
+func TestProvenance(t *testing.T) {
+	dir := t.TempDir()
+	dotenv := filepath.Join(dir, ".env")
+	secret := filepath.Join(dir, "password")
+	os.WriteFile(dotenv, []byte("PROVHOST=dotenv\nPROVPORT=8080\n"), 0o600)
+	os.WriteFile(secret, []byte("s3cr3t\n"), 0o600)
+
+	os.Setenv("PROVHOST", "localhost")
+	os.Setenv("PROVPASSWORD_FILE", secret)
+	defer func() {
+		os.Unsetenv("PROVHOST")
+		os.Unsetenv("PROVPASSWORD_FILE")
+	}()
+
+	var provenance Provenance
+	_, err := Assert(TestConfigProvenance{}, WithDotenv(dotenv), WithFileSecrets(), WithProvenance(&provenance))
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	expected := []Origin{
+		{Field: "Host", Variable: "PROVHOST", Source: SourceEnvironment, Value: "localhost"},
+		{Field: "Port", Variable: "PROVPORT", Source: SourceDotenv, Path: dotenv, Value: "8080"},
+		{Field: "Password", Variable: "PROVPASSWORD", Source: SourceFile, Path: secret, Value: "s3cr3t", Secret: true},
+		{Field: "Mode", Variable: "PROVMODE", Source: SourceDefault, Value: "remote", Default: true},
+		{Field: "Token", Variable: "PROVTOKEN", Source: SourceUnset},
+	}
+
+	if len(provenance) != len(expected) {
+		t.Fatalf("Expected %d origins, got %d: %+v", len(expected), len(provenance), provenance)
+	}
+	for i, origin := range expected {
+		if provenance[i] != origin {
+			t.Errorf("Expected %+v, got %+v", origin, provenance[i])
+		}
+	}
+}
+
+func TestProvenanceInvalidConfig(t *testing.T) {
+	var provenance Provenance
+	_, err := Assert(TestConfigProvenance{}, WithProvenance(&provenance))
+	if err == nil {
+		t.Fatalf("Expected error for missing variables but got none")
+	}
+
+	if len(provenance) != 5 || provenance[0].Source != SourceUnset {
+		t.Errorf("Expected the provenance of every field, got %+v", provenance)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	os.Setenv("PROVHOST", "localhost")
+	os.Setenv("PROVPASSWORD", "s3cr3t")
+	defer func() {
+		os.Unsetenv("PROVHOST")
+		os.Unsetenv("PROVPASSWORD")
+	}()
+
+	table := Explain(TestConfigProvenance{})
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+
+	expected := [][]string{
+		{"FIELD", "VARIABLE", "SOURCE", "VALUE"},
+		{"Host", "PROVHOST", "environment", `"localhost"`},
+		{"Port", "PROVPORT", "unset", "-"},
+		{"Password", "PROVPASSWORD", "environment", secretMask},
+		{"Mode", "PROVMODE", "default", `"remote"`},
+		{"Token", "PROVTOKEN", "unset", "-"},
+	}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d:\n%s", len(expected), len(lines), table)
+	}
+	for i, columns := range expected {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(columns, " ") {
+			t.Errorf("Expected line %v, got %v", columns, got)
+		}
+	}
+	if strings.Contains(table, "s3cr3t") {
+		t.Errorf("Expected the secret to be masked:\n%s", table)
+	}
+}
+
+func TestExplainMissingDotenv(t *testing.T) {
+	table := Explain(TestConfigProvenance{}, WithDotenv("/nonexistent/.env"))
+	if !strings.HasPrefix(table, "Configuration error:") {
+		t.Errorf("Expected a configuration error, got:\n%s", table)
+	}
+}
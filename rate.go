@@ -0,0 +1,105 @@
+package env
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The units of time a rate may be given in, e.g. `/s` or `/min`. Any Go
+// duration is accepted as well, e.g. `/5m`.
+var rateUnits = map[string]time.Duration{
+	"ns":     time.Nanosecond,
+	"us":     time.Microsecond,
+	"µs":     time.Microsecond,
+	"ms":     time.Millisecond,
+	"s":      time.Second,
+	"sec":    time.Second,
+	"second": time.Second,
+	"m":      time.Minute,
+	"min":    time.Minute,
+	"minute": time.Minute,
+	"h":      time.Hour,
+	"hour":   time.Hour,
+	"d":      24 * time.Hour,
+	"day":    24 * time.Hour,
+}
+
+const (
+	durationPattern = `([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+`
+	ratePattern     = `(` + unsignedNumberPattern + `)/(ns|us|µs|ms|s|sec|second|m|min|minute|h|hour|d|day|` + durationPattern + `)`
+)
+
+var rateRegex = regexp.MustCompile(`^` + ratePattern + `$`)
+
+// Type: Rate. The value is a number of events, written like the ints with the
+// `units` option, per unit of time, e.g. `100/s`, `5k/min` or `10/5m`.
+func rateParser(value string) (Rate, error) {
+	if !rateRegex.MatchString(value) {
+		return Rate{}, expectedError{"expected a rate like 100/s, 5000/min or 10/5m"}
+	}
+
+	count, unit, _ := strings.Cut(value, "/")
+	events, err := parseHumanNumber(count)
+	if err != nil {
+		return Rate{}, err
+	}
+
+	per, ok := rateUnits[unit]
+	if !ok {
+		if per, err = time.ParseDuration(unit); err != nil || per <= 0 {
+			return Rate{}, expectedError{"expected a positive unit of time like s, min or 5m"}
+		}
+	}
+
+	e, _ := events.Float64()
+	return Rate{events: e, per: per, unit: unit}, nil
+}
+
+// Rate is a number of events per unit of time, e.g. `100/s`. Rates are compared
+// by their events per second, so `60/min` is the same as `1/s`.
+type Rate struct {
+	events float64
+	per    time.Duration
+	unit   string // As written, e.g. `min`
+}
+
+// Events returns the number of events per Unit, e.g. 100 for `100/s`
+func (r Rate) Events() float64 {
+	return r.events
+}
+
+// Unit returns the unit of time of the rate, e.g. a minute for `5000/min`
+func (r Rate) Unit() time.Duration {
+	return r.per
+}
+
+// Per returns the number of events in the given time, e.g. Per(time.Second)
+// is 10 for `600/min`
+func (r Rate) Per(d time.Duration) float64 {
+	return r.events * float64(d) / float64(r.per)
+}
+
+// Interval returns the time between two events, e.g. 100ms for `10/s`, or 0
+// if the rate is zero
+func (r Rate) Interval() time.Duration {
+	if r.events == 0 {
+		return 0
+	}
+	return time.Duration(float64(r.per) / r.events)
+}
+
+func (r Rate) perSecond() float64 {
+	return r.Per(time.Second)
+}
+
+// String returns the rate with the unit as written, e.g. `5000/min` for
+// `5k/min`
+func (r Rate) String() string {
+	if r.per == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s", strconv.FormatFloat(r.events, 'g', -1, 64), r.unit)
+}
@@ -0,0 +1,70 @@
+package env
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateParser(t *testing.T) {
+	tests := []struct {
+		value     string
+		events    float64
+		unit      time.Duration
+		perSecond float64
+		interval  time.Duration
+		str       string
+	}{
+		{value: "100/s", events: 100, unit: time.Second, perSecond: 100, interval: 10 * time.Millisecond, str: "100/s"},
+		{value: "5000/min", events: 5000, unit: time.Minute, perSecond: 5000.0 / 60, interval: 12 * time.Millisecond, str: "5000/min"},
+		{value: "5k/min", events: 5000, unit: time.Minute, perSecond: 5000.0 / 60, interval: 12 * time.Millisecond, str: "5000/min"},
+		{value: "1_000/hour", events: 1000, unit: time.Hour, perSecond: 1000.0 / 3600, interval: 3600 * time.Millisecond, str: "1000/hour"},
+		{value: "10/5m", events: 10, unit: 5 * time.Minute, perSecond: 10.0 / 300, interval: 30 * time.Second, str: "10/5m"},
+		{value: "0.5/s", events: 0.5, unit: time.Second, perSecond: 0.5, interval: 2 * time.Second, str: "0.5/s"},
+		{value: "2/d", events: 2, unit: 24 * time.Hour, perSecond: 2.0 / 86400, interval: 12 * time.Hour, str: "2/d"},
+		{value: "0/s", events: 0, unit: time.Second, perSecond: 0, interval: 0, str: "0/s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			rate, err := rateParser(tt.value)
+			if err != nil {
+				t.Fatalf("Expected no error for value '%s' but got: %v", tt.value, err)
+			}
+			if rate.Events() != tt.events || rate.Unit() != tt.unit {
+				t.Errorf("Expected %v per %v, got %v per %v", tt.events, tt.unit, rate.Events(), rate.Unit())
+			}
+			if rate.Per(time.Second) != tt.perSecond {
+				t.Errorf("Expected %v per second, got %v", tt.perSecond, rate.Per(time.Second))
+			}
+			if rate.Interval() != tt.interval {
+				t.Errorf("Expected an interval of %v, got %v", tt.interval, rate.Interval())
+			}
+			if rate.String() != tt.str {
+				t.Errorf("Expected '%s', got '%s'", tt.str, rate.String())
+			}
+		})
+	}
+}
+
+func TestRateParserErrors(t *testing.T) {
+	tests := []string{
+		"100",
+		"100/",
+		"/s",
+		"-100/s",
+		"100/fortnight",
+		"100/0s",
+		"100/S",
+		"100 / s",
+		"ten/s",
+		"",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			if rate, err := rateParser(value); err == nil {
+				t.Errorf("Expected error for value '%s' but got %v", value, rate)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldError describes an environment variable that is missing or invalid
+type FieldError struct {
+	Field    string   // Path of the field, e.g. `Database.Host`
+	Variable string   // Name of the environment variable, e.g. `DATABASE_HOST`
+	Type     string   // Type of the field, e.g. `IPv4` or `[]int`
+	Value    string   // The offending value, empty when the variable is missing
+	Reason   string   // Why the variable is missing or invalid, if known
+	Values   []string // The allowed values, if restricted
+	Secret   bool     // Whether the value must not be shown
+}
+
+// Returns the label used to report the variable, e.g. `DatabaseURL (DB_URL)`.
+// Errors that don't come from a struct field only have the variable name.
+func (e FieldError) label() string {
+	if e.Field == "" {
+		return e.Variable
+	}
+	return fmt.Sprintf("%s (%s)", e.Field, e.Variable)
+}
+
+// Report is the structured result of validating a configuration
+type Report struct {
+	Missing []FieldError
+	Invalid []FieldError
+
+	// Checks of the Validate method of config structs that failed, prefixed
+	// with the path of the struct
+	Failed []string
+
+	// Variables that were given but are not read by the configuration
+	Unknown []string
+}
+
+// Valid reports whether there are no missing or invalid variables and no
+// failed checks. Unknown variables don't make a report invalid.
+func (r *Report) Valid() bool {
+	return len(r.Missing) == 0 && len(r.Invalid) == 0 && len(r.Failed) == 0
+}
+
+// Returns the errors formatted as returned by Validate. Values of secrets are
+// masked.
+func (r *Report) legacy() ([]string, []invalidType) {
+	var missing []string
+	var invalid []invalidType
+
+	for _, e := range r.Missing {
+		label := e.label()
+		if e.Reason != "" {
+			label += ": " + e.Reason
+		}
+		missing = append(missing, label)
+	}
+	for _, e := range r.Invalid {
+		value := e.Value
+		if e.Secret {
+			value = secretMask
+		}
+		invalid = append(invalid, invalidType{name: e.label(), value: value, reason: e.Reason})
+	}
+
+	return missing, invalid
+}
+
+// ValidateMap validates the configuration against the given variables instead
+// of the environment of the process, e.g. the env block of a Kubernetes
+// manifest or a dotenv file. The Validate methods of the config structs are
+// called when all the fields are valid, and the variables in the map that the
+// configuration does not read are reported as unknown.
+//
+// The filesystem is left as it is: the `create` option doesn't create
+// directories and `writable` is not checked. Other checks of paths, and the
+// files of certificates and keys, are made against the local filesystem.
+func ValidateMap(config any, variables map[string]string) *Report {
+	src := mapSource(variables)
+	v := validate(config, src)
+	report := &Report{Missing: v.missing, Invalid: v.invalid}
+
+	if report.Valid() {
+		result := reflect.New(reflect.TypeOf(config)).Elem()
+		populate(result, scope{}, v.environment)
+		report.Failed = runValidators(result, "")
+	}
+
+	for name := range variables {
+		if !src.read[name] {
+			report.Unknown = append(report.Unknown, name)
+		}
+	}
+	sort.Strings(report.Unknown)
+
+	return report
+}
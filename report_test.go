@@ -0,0 +1,130 @@
+package env
+
+import (
+	"errors"
+	"testing"
+)
+
+type TestMapDatabase struct {
+	Host     string `env:"required"`
+	Password string `env:"required,secret"`
+}
+
+type TestMapConfig struct {
+	Port     int  `env:"required"`
+	Debug    bool `env:"optional,default='false'"`
+	Database TestMapDatabase
+}
+
+type TestMapRange struct {
+	Min int `env:"required"`
+	Max int `env:"required"`
+}
+
+func (r TestMapRange) Validate() error {
+	if r.Min > r.Max {
+		return errors.New("Min must not exceed Max")
+	}
+	return nil
+}
+
+func TestValidateMap(t *testing.T) {
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		expectedValid   bool
+		expectedMissing []string
+		expectedInvalid []string
+		expectedUnknown []string
+	}{
+		{
+			name: "valid map",
+			variables: map[string]string{
+				"PORT":              "8080",
+				"DATABASE_HOST":     "db",
+				"DATABASE_PASSWORD": "secret",
+			},
+			expectedValid: true,
+		},
+		{
+			name: "missing and invalid",
+			variables: map[string]string{
+				"PORT":          "http",
+				"DATABASE_HOST": "db",
+			},
+			expectedMissing: []string{"DATABASE_PASSWORD"},
+			expectedInvalid: []string{"PORT"},
+		},
+		{
+			name: "unknown variables",
+			variables: map[string]string{
+				"PORT":              "8080",
+				"DATABASE_HOST":     "db",
+				"DATABASE_PASSWORD": "secret",
+				"DATABSE_HOST":      "typo",
+				"HOME":              "/root",
+			},
+			expectedValid:   true,
+			expectedUnknown: []string{"DATABSE_HOST", "HOME"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestMapConfig{}, tt.variables)
+
+			if report.Valid() != tt.expectedValid {
+				t.Errorf("Expected valid=%v, got %+v", tt.expectedValid, report)
+			}
+			if len(report.Missing) != len(tt.expectedMissing) {
+				t.Errorf("Expected missing %v, got %+v", tt.expectedMissing, report.Missing)
+			}
+			for i, name := range tt.expectedMissing {
+				if i < len(report.Missing) && report.Missing[i].Variable != name {
+					t.Errorf("Expected missing '%s', got %+v", name, report.Missing[i])
+				}
+			}
+			if len(report.Invalid) != len(tt.expectedInvalid) {
+				t.Errorf("Expected invalid %v, got %+v", tt.expectedInvalid, report.Invalid)
+			}
+			for i, name := range tt.expectedInvalid {
+				if i < len(report.Invalid) && report.Invalid[i].Variable != name {
+					t.Errorf("Expected invalid '%s', got %+v", name, report.Invalid[i])
+				}
+			}
+			if len(report.Unknown) != len(tt.expectedUnknown) {
+				t.Errorf("Expected unknown %v, got %v", tt.expectedUnknown, report.Unknown)
+			}
+			for i, name := range tt.expectedUnknown {
+				if i < len(report.Unknown) && report.Unknown[i] != name {
+					t.Errorf("Expected unknown '%s', got '%s'", name, report.Unknown[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMapFieldError(t *testing.T) {
+	report := ValidateMap(TestMapConfig{}, map[string]string{"PORT": "http"})
+
+	invalid := report.Invalid[0]
+	if invalid.Field != "Port" || invalid.Type != "int" || invalid.Value != "http" {
+		t.Errorf("Unexpected invalid field: %+v", invalid)
+	}
+
+	missing := report.Missing[1]
+	if missing.Field != "Database.Password" || !missing.Secret {
+		t.Errorf("Unexpected missing field: %+v", missing)
+	}
+}
+
+func TestValidateMapValidator(t *testing.T) {
+	report := ValidateMap(TestMapRange{}, map[string]string{"MIN": "5", "MAX": "1"})
+
+	if report.Valid() {
+		t.Fatalf("Expected report to be invalid")
+	}
+	if len(report.Failed) != 1 || report.Failed[0] != "TestMapRange: Min must not exceed Max" {
+		t.Errorf("Unexpected failed checks: %v", report.Failed)
+	}
+}
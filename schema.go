@@ -0,0 +1,320 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema describing the environment variables read by a
+// config struct. Environment variables are always strings, so every property
+// is a string and the type of the field is expressed as a pattern.
+type Schema struct {
+	Schema     string                    `json:"$schema"`
+	Title      string                    `json:"title,omitempty"`
+	Type       string                    `json:"type"`
+	Properties map[string]SchemaProperty `json:"properties"`
+	Required   []string                  `json:"required,omitempty"`
+}
+
+// SchemaProperty describes a single environment variable
+type SchemaProperty struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	Default     *string  `json:"default,omitempty"`
+	MinLength   int      `json:"minLength,omitempty"`
+	Minimum     string   `json:"x-minimum,omitempty"` // Bounds of numbers, e.g. `50%`
+	Maximum     string   `json:"x-maximum,omitempty"`
+	Secret      bool     `json:"x-secret,omitempty"`
+	EnvType     string   `json:"x-env-type,omitempty"` // Type of the field, e.g. `IPv4`
+}
+
+// JSONSchema returns the JSON Schema of the environment variables read by the
+// config struct, so that deployment tooling can check them before they reach
+// the application. Conditional requirements are not part of the schema.
+func JSONSchema(config any) ([]byte, error) {
+	return json.MarshalIndent(NewSchema(config), "", "  ")
+}
+
+// NewSchema returns the schema of the environment variables read by the config
+// struct, see JSONSchema
+func NewSchema(config any) Schema {
+	schema := Schema{
+		Schema:     schemaDialect,
+		Title:      reflect.TypeOf(config).Name(),
+		Type:       "object",
+		Properties: make(map[string]SchemaProperty),
+	}
+
+	for _, v := range Describe(config) {
+		property := SchemaProperty{
+			Type:        "string",
+			Description: v.Description,
+			Pattern:     v.pattern(),
+			Minimum:     v.Min,
+			Maximum:     v.Max,
+			Secret:      v.Secret,
+			EnvType:     v.Type,
+		}
+		// Allowed networks can't be expressed with an enum, and neither can
+		// values that are transformed before being checked
+		if v.Separator == "" && !isNetworkType(v.Type) && len(v.Transforms) == 0 {
+			property.Enum = v.Values
+		}
+		if v.Default != nil && !v.Secret {
+			property.Default = v.Default
+		}
+
+		// Empty variables are treated as not set
+		if v.Required {
+			property.MinLength = 1
+			schema.Required = append(schema.Required, v.Name)
+		}
+
+		schema.Properties[v.Name] = property
+	}
+
+	return schema
+}
+
+// Returns the pattern the values of the variable must match. The pattern of
+// a slice matches a list of elements joined by the separator, where each
+// element matches the type or one of the allowed values. Values that are
+// transformed, e.g. lowercased, have no pattern since it would not apply.
+func (v Variable) pattern() string {
+	if len(v.Transforms) > 0 {
+		return ""
+	}
+
+	element := patternFor(strings.TrimPrefix(v.Type, "[]"))
+	if v.DefaultPort != "" {
+		element = hostPortPattern(true)
+	}
+	if v.Units {
+		element = humanNumberPattern
+	}
+	if v.BoolVocabulary != "" {
+		element = boolPattern(boolVocabularies[v.BoolVocabulary])
+	}
+
+	// Allowed networks are not part of the pattern, only the type is checked
+	network := isNetworkType(strings.TrimPrefix(v.Type, "[]"))
+
+	if v.Separator == "" {
+		// Allowed values of scalars are expressed with an enum instead
+		if element == "" || (len(v.Values) > 0 && !network) {
+			return ""
+		}
+		return "^(" + element + ")$"
+	}
+
+	// Quoted CSV elements can't be checked element by element
+	if v.Format != "" {
+		return ""
+	}
+
+	if len(v.Values) > 0 && !network {
+		values := make([]string, len(v.Values))
+		for i, value := range v.Values {
+			values[i] = regexp.QuoteMeta(value)
+		}
+		element = strings.Join(values, "|")
+	}
+	if element == "" {
+		return ""
+	}
+
+	element = "(" + element + ")"
+	if v.OmitEmpty {
+		element += "?"
+	}
+	// Whitespace around elements is trimmed, unless it separates them
+	if v.Trim && strings.TrimSpace(v.Separator) == v.Separator {
+		element = `\s*` + element + `\s*`
+	}
+
+	sep := regexp.QuoteMeta(v.Separator)
+	return "^" + element + "(" + sep + element + ")*$"
+}
+
+const (
+	octetPattern = `25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9]`
+	ipv4Pattern  = `((` + octetPattern + `)\.){3}(` + octetPattern + `)`
+
+	// IPv6 addresses are only checked loosely, the pattern of every valid
+	// form would be unreadable
+	ipv6Pattern = `([0-9A-Fa-f]{0,4}:){2,7}([0-9A-Fa-f]{0,4}|` + ipv4Pattern + `)(%[0-9A-Za-z_.-]+)?`
+)
+
+// Returns the unanchored pattern matching the values of the given type, or an
+// empty string if any value is valid
+func patternFor(fieldType string) string {
+	const ipv4, ipv6 = ipv4Pattern, ipv6Pattern
+	const ip = ipv4 + `|` + ipv6
+	const cidr = `(` + ipv4 + `)/(3[0-2]|[12]?[0-9])|(` + ipv6 + `)/(12[0-8]|1[01][0-9]|[1-9]?[0-9])`
+	const ipSetElement = `\s*(` + ip + `)(/[0-9]{1,3})?\s*`
+
+	switch strings.ToLower(fieldType) {
+	case "bool":
+		return boolPattern(BoolStandard)
+	case "int":
+		return `[+-]?[0-9]+`
+	case "ipv4":
+		return ipv4
+	case "ipv6":
+		return ipv6
+	case "ip":
+		return ip
+	case "cidr":
+		return cidr
+	case "ipset":
+		return ipSetElement + `(,` + ipSetElement + `)*`
+	case "url":
+		return `([A-Za-z][A-Za-z0-9+.-]*:|/)\S*`
+	case "httpurl":
+		return `https?://\S+`
+	case "hostport":
+		return hostPortPattern(false)
+	case "percent":
+		return percentPattern
+	case "rate":
+		return ratePattern
+	default:
+		return ""
+	}
+}
+
+// Returns the pattern of the words of the vocabulary, ignoring case. Inline
+// flags like `(?i)` are not portable across JSON Schema validators, so each
+// letter matches both cases instead, e.g. `[Tt][Rr][Uu][Ee]`.
+func boolPattern(vocabulary BoolVocabulary) string {
+	words := vocabulary.words()
+	patterns := make([]string, len(words))
+	for i, word := range words {
+		var b strings.Builder
+		for _, c := range word {
+			if upper := unicode.ToUpper(c); upper != c {
+				fmt.Fprintf(&b, "[%c%c]", upper, c)
+			} else {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		}
+		patterns[i] = b.String()
+	}
+	return strings.Join(patterns, "|")
+}
+
+// Returns the pattern of host:port addresses. With a default port the port
+// may be left out, and IPv6 hosts may be given without brackets.
+func hostPortPattern(defaultPort bool) string {
+	const port = `6553[0-5]|655[0-2][0-9]|65[0-4][0-9]{2}|6[0-4][0-9]{3}|[1-5][0-9]{4}|[1-9][0-9]{0,3}`
+	const host = `\[(` + ipv6Pattern + `)\]|[0-9A-Za-z_.-]*`
+
+	if defaultPort {
+		return `(` + host + `)(:(` + port + `))?|` + ipv6Pattern
+	}
+	return `(` + host + `):(` + port + `)`
+}
+
+// Check validates the variables against the schema: required variables must
+// be set, and the values must match the pattern, be one of the enum and be
+// within the bounds of numbers. The variables that are not properties of the
+// schema are reported as unknown.
+func (s Schema) Check(variables map[string]string) *Report {
+	report := &Report{}
+
+	for _, name := range s.Required {
+		if variables[name] == "" {
+			report.Missing = append(report.Missing, s.Properties[name].fieldError(name, ""))
+		}
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := variables[name]
+		property, ok := s.Properties[name]
+		if !ok {
+			report.Unknown = append(report.Unknown, name)
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		if len(property.Enum) > 0 && !isValueAllowed(value, property.Enum) {
+			e := property.fieldError(name, value)
+			e.Reason = "must be one of: " + strings.Join(property.Enum, ", ")
+			report.Invalid = append(report.Invalid, e)
+			continue
+		}
+
+		if property.Pattern != "" {
+			re, err := regexp.Compile(property.Pattern)
+			if err != nil {
+				e := property.fieldError(name, value)
+				e.Reason = fmt.Sprintf("invalid pattern in schema: %v", err)
+				report.Invalid = append(report.Invalid, e)
+			} else if !re.MatchString(value) {
+				e := property.fieldError(name, value)
+				e.Reason = "must match " + property.Pattern
+				report.Invalid = append(report.Invalid, e)
+				continue
+			}
+		}
+
+		if err := property.checkBounds(value); err != nil {
+			e := property.fieldError(name, value)
+			e.Reason = err.Error()
+			report.Invalid = append(report.Invalid, e)
+		}
+	}
+
+	return report
+}
+
+// Checks that the value is within the bounds of the property. The bounds of
+// slices apply to each element, but the schema doesn't know how to split them
+// so they are not checked.
+func (p SchemaProperty) checkBounds(value string) error {
+	if p.Minimum == "" && p.Maximum == "" {
+		return nil
+	}
+	parse, ok := numberParserFor(p.EnvType)
+	if !ok {
+		return nil
+	}
+
+	b, err := parseBounds(p.Minimum, p.Maximum, parse)
+	if err != nil {
+		return fmt.Errorf("invalid bounds in schema: %v", err)
+	}
+
+	parsed, err := parse(value)
+	if err != nil {
+		return err
+	}
+	return b.check(parsed)
+}
+
+func (p SchemaProperty) fieldError(name string, value string) FieldError {
+	return FieldError{
+		Variable: name,
+		Type:     p.EnvType,
+		Value:    value,
+		Values:   p.Enum,
+		Secret:   p.Secret,
+	}
+}
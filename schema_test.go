@@ -0,0 +1,187 @@
+package env
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+type TestSchemaConfig struct {
+	Port     int      `env:"optional,default='8080',desc='Port to listen on'"`
+	Env      string   `env:"required,values='dev,staging,prod',name='APP_ENV'"`
+	Debug    bool     `env:"required"`
+	Password string   `env:"optional,secret,default='changeme'"`
+	Hosts    []IPv4   `env:"optional,separator=','"`
+	Names    []string `env:"optional"`
+	Modes    []string `env:"optional,separator=',',values='read,write',omitempty"`
+	API      HTTPURL  `env:"required"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema(TestSchemaConfig{})
+	if err != nil {
+		t.Fatalf("JSONSchema failed: %v", err)
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if schema.Schema != schemaDialect || schema.Type != "object" || schema.Title != "TestSchemaConfig" {
+		t.Errorf("Unexpected schema header: %+v", schema)
+	}
+
+	expectedRequired := []string{"APP_ENV", "DEBUG", "API"}
+	if len(schema.Required) != len(expectedRequired) {
+		t.Fatalf("Expected required %v, got %v", expectedRequired, schema.Required)
+	}
+	for i, name := range expectedRequired {
+		if schema.Required[i] != name {
+			t.Errorf("Expected required %v, got %v", expectedRequired, schema.Required)
+		}
+	}
+
+	port := schema.Properties["PORT"]
+	if port.Type != "string" || port.Default == nil || *port.Default != "8080" || port.Description != "Port to listen on" {
+		t.Errorf("Unexpected PORT property: %+v", port)
+	}
+
+	env := schema.Properties["APP_ENV"]
+	if len(env.Enum) != 3 || env.Pattern != "" || env.MinLength != 1 {
+		t.Errorf("Unexpected APP_ENV property: %+v", env)
+	}
+
+	password := schema.Properties["PASSWORD"]
+	if password.Default != nil || !password.Secret {
+		t.Errorf("Expected secret without default: %+v", password)
+	}
+
+	if names := schema.Properties["NAMES"]; names.Pattern != "" {
+		t.Errorf("Expected no pattern for []string, got '%s'", names.Pattern)
+	}
+}
+
+func TestSchemaPatterns(t *testing.T) {
+	schema := NewSchema(TestSchemaConfig{})
+
+	tests := []struct {
+		name     string
+		variable string
+		value    string
+		expected bool
+	}{
+		{"valid int", "PORT", "8080", true},
+		{"negative int", "PORT", "-1", true},
+		{"invalid int", "PORT", "80a", false},
+		{"valid bool", "DEBUG", "yes", true},
+		{"invalid bool", "DEBUG", "maybe", false},
+		{"valid ipv4 list", "HOSTS", "10.0.0.1,192.168.1.1", true},
+		{"invalid ipv4 in list", "HOSTS", "10.0.0.1,256.0.0.1", false},
+		{"wrong separator", "HOSTS", "10.0.0.1 192.168.1.1", false},
+		{"ipv4 list with spaces", "HOSTS", "10.0.0.1, 192.168.1.1", true},
+		{"allowed elements", "MODES", "read,write", true},
+		{"allowed elements with empty one", "MODES", "read,,write,", true},
+		{"element not allowed", "MODES", "read,delete", false},
+		{"valid http url", "API", "https://api.example.com", true},
+		{"invalid http url", "API", "ftp://files.example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := schema.Properties[tt.variable].Pattern
+			matched := regexp.MustCompile(pattern).MatchString(tt.value)
+			if matched != tt.expected {
+				t.Errorf("Expected %v for '%s' with pattern '%s'", tt.expected, tt.value, pattern)
+			}
+
+			// The library agrees with the pattern
+			value := tt.value
+			if tt.variable == "HOSTS" || tt.variable == "MODES" {
+				report := ValidateMap(TestSchemaConfig{}, map[string]string{
+					tt.variable: value, "APP_ENV": "dev", "DEBUG": "true", "API": "https://api.example.com",
+				})
+				if report.Valid() != tt.expected {
+					t.Errorf("Pattern and parser disagree for '%s': %+v", value, report.Invalid)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaCheck(t *testing.T) {
+	schema := NewSchema(TestSchemaConfig{})
+
+	report := schema.Check(map[string]string{
+		"APP_ENV":  "production",
+		"DEBUG":    "",
+		"PORT":     "80a",
+		"API":      "https://api.example.com",
+		"PASSWORD": "hunter2",
+		"UNUSED":   "1",
+	})
+
+	if len(report.Missing) != 1 || report.Missing[0].Variable != "DEBUG" || report.Missing[0].Type != "bool" {
+		t.Errorf("Unexpected missing: %+v", report.Missing)
+	}
+
+	if len(report.Invalid) != 2 {
+		t.Fatalf("Expected 2 invalid, got %+v", report.Invalid)
+	}
+	if report.Invalid[0].Variable != "APP_ENV" || report.Invalid[0].Reason != "must be one of: dev, staging, prod" {
+		t.Errorf("Unexpected invalid: %+v", report.Invalid[0])
+	}
+	if report.Invalid[1].Variable != "PORT" {
+		t.Errorf("Unexpected invalid: %+v", report.Invalid[1])
+	}
+
+	if len(report.Unknown) != 1 || report.Unknown[0] != "UNUSED" {
+		t.Errorf("Unexpected unknown: %v", report.Unknown)
+	}
+}
+
+func TestSchemaNetworkPatterns(t *testing.T) {
+	type config struct {
+		V6      IPv6  `env:"required"`
+		Any     IP    `env:"required"`
+		Network CIDR  `env:"required"`
+		Allowed IPSet `env:"required"`
+		Admin   IPv4  `env:"required,values='10.0.0.0/8'"`
+	}
+	schema := NewSchema(config{})
+
+	tests := []struct {
+		variable string
+		value    string
+		expected bool
+	}{
+		{"V6", "2001:db8::1", true},
+		{"V6", "::1", true},
+		{"V6", "fe80::1%eth0", true},
+		{"V6", "10.0.0.1", false},
+		{"ANY", "10.0.0.1", true},
+		{"ANY", "2001:db8::1", true},
+		{"ANY", "localhost", false},
+		{"NETWORK", "10.0.0.0/8", true},
+		{"NETWORK", "2001:db8::/32", true},
+		{"NETWORK", "10.0.0.0/33", false},
+		{"NETWORK", "10.0.0.0", false},
+		{"ALLOWED", "10.0.0.0/8, 192.168.1.1,::1", true},
+		{"ALLOWED", "10.0.0.0/8,localhost", false},
+		{"ADMIN", "192.168.1.1", true},
+		{"ADMIN", "1.2.3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.variable+"="+tt.value, func(t *testing.T) {
+			pattern := schema.Properties[tt.variable].Pattern
+			if matched := regexp.MustCompile(pattern).MatchString(tt.value); matched != tt.expected {
+				t.Errorf("Expected %v for '%s' with pattern '%s'", tt.expected, tt.value, pattern)
+			}
+		})
+	}
+
+	if admin := schema.Properties["ADMIN"]; len(admin.Enum) != 0 {
+		t.Errorf("Expected no enum for allowed networks, got %v", admin.Enum)
+	}
+}
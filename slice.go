@@ -0,0 +1,179 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Elements of CSV lists may be quoted to contain the separator
+const formatCSV = "csv"
+
+// Returns the separator as written in a tag, e.g. `\n` for a newline
+func escapeSeparator(sep string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(sep)
+}
+
+// How the elements of a slice are split and checked, as given in the tag
+type sliceOptions struct {
+	sep       string
+	format    string
+	trim      bool     // Remove the whitespace around elements, unless `notrim`
+	omitEmpty bool     // Drop empty elements, e.g. after a trailing separator
+	unique    bool     // Reject duplicated elements
+	values    []string // The allowed values of each element
+}
+
+func getSliceOptions(tag string) sliceOptions {
+	return sliceOptions{
+		sep:       getSeparator(tag),
+		format:    getFormat(tag),
+		trim:      !isNoTrim(tag),
+		omitEmpty: isOmitEmpty(tag),
+		unique:    isUnique(tag),
+		values:    getValues(tag),
+	}
+}
+
+// Splits the value of a slice variable into its elements, trimming them and
+// dropping the empty ones if the options say so
+func splitSlice(value string, o sliceOptions) ([]string, error) {
+	var elements []string
+	if o.format == formatCSV {
+		var err error
+		if elements, err = splitCSV(value, o.sep, o.trim); err != nil {
+			return nil, err
+		}
+	} else {
+		elements = strings.Split(value, o.sep)
+		if o.trim {
+			for i, element := range elements {
+				elements[i] = strings.TrimSpace(element)
+			}
+		}
+	}
+
+	if !o.omitEmpty {
+		return elements, nil
+	}
+
+	nonEmpty := elements[:0]
+	for _, element := range elements {
+		if element != "" {
+			nonEmpty = append(nonEmpty, element)
+		}
+	}
+	return nonEmpty, nil
+}
+
+// Splits a CSV list. Elements in double quotes may contain the separator, and
+// a double quote within them is written twice, e.g. `"a,b","say ""hi"""`. When
+// trimming, the whitespace around quoted elements is ignored but the one
+// within the quotes is kept.
+func splitCSV(value string, sep string, trim bool) ([]string, error) {
+	var elements []string
+	var element strings.Builder
+	quoted, wasQuoted := false, false
+
+	endElement := func() {
+		e := element.String()
+		if trim && !wasQuoted {
+			e = strings.TrimSpace(e)
+		}
+		elements = append(elements, e)
+		element.Reset()
+		wasQuoted = false
+	}
+
+	for i := 0; i < len(value); {
+		switch {
+		case quoted && strings.HasPrefix(value[i:], `""`):
+			element.WriteByte('"')
+			i += 2
+		case quoted && value[i] == '"':
+			quoted = false
+			i++
+			for trim && i < len(value) && isBlank(value[i]) && !strings.HasPrefix(value[i:], sep) {
+				i++
+			}
+			if i < len(value) && !strings.HasPrefix(value[i:], sep) {
+				return nil, fmt.Errorf("unexpected character after quoted element %d", len(elements))
+			}
+		case quoted:
+			element.WriteByte(value[i])
+			i++
+		case value[i] == '"' && !wasQuoted && (element.Len() == 0 || trim && strings.TrimSpace(element.String()) == ""):
+			element.Reset()
+			quoted, wasQuoted = true, true
+			i++
+		case strings.HasPrefix(value[i:], sep):
+			endElement()
+			i += len(sep)
+		default:
+			element.WriteByte(value[i])
+			i++
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in element %d", len(elements))
+	}
+	endElement()
+	return elements, nil
+}
+
+func isBlank(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// Checks every element against the allowed values, reporting the ones that
+// are not allowed by their index
+func checkAllowedElements(fieldType string, elements []string, values []string) error {
+	var failed []int
+	for i, element := range elements {
+		if !isAllowedFor(fieldType, element, values) {
+			failed = append(failed, i)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s not allowed", describeElements(failed))
+	}
+	return nil
+}
+
+// Checks that no parsed element is repeated, reporting the repeated ones by
+// their index. Elements are compared deeply, since some types like IPSet
+// can't be compared with ==, and their string forms may hide differences,
+// like the redacted passwords of DSNs.
+func checkUniqueElements(parsed []any) error {
+	var duplicated []int
+	for i, element := range parsed {
+		for _, previous := range parsed[:i] {
+			if reflect.DeepEqual(element, previous) {
+				duplicated = append(duplicated, i)
+				break
+			}
+		}
+	}
+
+	if len(duplicated) > 0 {
+		return fmt.Errorf("%s duplicated", describeElements(duplicated))
+	}
+	return nil
+}
+
+// Returns e.g. `element 2 is` or `elements 1, 3 are`, so that errors point at
+// the failing elements without showing their values, which may be secret
+func describeElements(indexes []int) string {
+	if len(indexes) == 1 {
+		return fmt.Sprintf("element %d is", indexes[0])
+	}
+
+	list := make([]string, len(indexes))
+	for i, index := range indexes {
+		list[i] = strconv.Itoa(index)
+	}
+	return fmt.Sprintf("elements %s are", strings.Join(list, ", "))
+}
@@ -1,10 +1,29 @@
 package env
 
 import (
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
 
+// Returns the parser the validator uses for the elements of a slice field of
+// the named type, e.g. `ipv4`
+func sliceParser(fieldName string, fieldType string) func(string) (any, error) {
+	elementTypes := map[string]reflect.Type{
+		"string":  reflect.TypeOf(""),
+		"int":     reflect.TypeOf(0),
+		"bool":    reflect.TypeOf(false),
+		"url":     reflect.TypeOf(URL("")),
+		"httpurl": reflect.TypeOf(HTTPURL("")),
+		"ipv4":    reflect.TypeOf(IPv4("")),
+	}
+
+	field := reflect.StructField{Name: fieldName, Type: reflect.SliceOf(elementTypes[strings.ToLower(fieldType)])}
+	v := &validation{source: mapSource(nil), environment: make(envMapType)}
+	return v.parserFor(field)
+}
+
 func TestSliceValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -302,7 +321,7 @@ func TestSliceValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := validateAndParseSlice(tt.fieldName, tt.fieldType, tt.value, tt.sep)
+			result, err := parseElements(strings.Split(tt.value, tt.sep), sliceParser(tt.fieldName, tt.fieldType))
 
 			if tt.expectError && err == nil {
 				t.Errorf("Expected error for %s but got none", tt.description)
@@ -368,7 +387,7 @@ func TestSliceWithCustomSeparators(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := validateAndParseSlice("TestField", tt.fieldType, tt.value, tt.sep)
+			result, err := parseElements(strings.Split(tt.value, tt.sep), sliceParser("TestField", tt.fieldType))
 
 			if tt.expectError && err == nil {
 				t.Errorf("Expected error but got none")
@@ -399,7 +418,7 @@ func TestSliceErrorHandling(t *testing.T) {
 			value:         "1,2,invalid,4",
 			sep:           ",",
 			expectError:   true,
-			errorContains: "invalid slice",
+			errorContains: "invalid slice: element 2 is not valid",
 		},
 		{
 			name:          "mixed valid and invalid bools",
@@ -415,7 +434,7 @@ func TestSliceErrorHandling(t *testing.T) {
 			value:         "192.168.1.1,not-an-ip,10.0.0.1",
 			sep:           ",",
 			expectError:   true,
-			errorContains: "invalid slice",
+			errorContains: "invalid slice: element 1 is not valid",
 		},
 		{
 			name:          "mixed valid and invalid URLs",
@@ -425,6 +444,14 @@ func TestSliceErrorHandling(t *testing.T) {
 			expectError:   true,
 			errorContains: "invalid slice",
 		},
+		{
+			name:          "several invalid elements",
+			fieldType:     "int",
+			value:         "x,2,y",
+			sep:           ",",
+			expectError:   true,
+			errorContains: "invalid slice: elements 0, 2 are not valid",
+		},
 		{
 			name:          "mixed valid and invalid HTTPURLs",
 			fieldType:     "httpurl",
@@ -437,7 +464,7 @@ func TestSliceErrorHandling(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := validateAndParseSlice("TestField", tt.fieldType, tt.value, tt.sep)
+			result, err := parseElements(strings.Split(tt.value, tt.sep), sliceParser("TestField", tt.fieldType))
 
 			if tt.expectError {
 				if err == nil {
@@ -456,3 +483,353 @@ func TestSliceErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitSlice(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		sep         string
+		format      string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "multi-character separator",
+			value:    "a, b, c",
+			sep:      ", ",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "double colon separator",
+			value:    "a::b::c",
+			sep:      "::",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "newline separator",
+			value:    "a\nb",
+			sep:      "\n",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "csv without quotes",
+			value:    "a,b,c",
+			sep:      ",",
+			format:   formatCSV,
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "csv with separator in quotes",
+			value:    `"a,b",c`,
+			sep:      ",",
+			format:   formatCSV,
+			expected: []string{"a,b", "c"},
+		},
+		{
+			name:     "csv with escaped quote",
+			value:    `"say ""hi""",x`,
+			sep:      ",",
+			format:   formatCSV,
+			expected: []string{`say "hi"`, "x"},
+		},
+		{
+			name:     "csv with multi-character separator",
+			value:    `"a; b"; c`,
+			sep:      "; ",
+			format:   formatCSV,
+			expected: []string{"a; b", "c"},
+		},
+		{
+			name:     "csv with empty quoted element",
+			value:    `"",a`,
+			sep:      ",",
+			format:   formatCSV,
+			expected: []string{"", "a"},
+		},
+		{
+			name:     "csv with quote inside unquoted element",
+			value:    `a"b,c`,
+			sep:      ",",
+			format:   formatCSV,
+			expected: []string{`a"b`, "c"},
+		},
+		{
+			name:        "csv with unterminated quote",
+			value:       `"a,b`,
+			sep:         ",",
+			format:      formatCSV,
+			expectError: true,
+		},
+		{
+			name:        "csv with text after quoted element",
+			value:       `"a"b,c`,
+			sep:         ",",
+			format:      formatCSV,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := splitSlice(tt.value, sliceOptions{sep: tt.sep, format: tt.format})
+
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !equalStringSlices(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigSliceFormats struct {
+	Hosts  []string `env:"required,separator=', ',name='SLICEHOSTS'"`
+	Lines  []int    `env:"required,separator='\\n',name='SLICELINES'"`
+	Labels []string `env:"required,format='csv',name='SLICELABELS'"`
+}
+
+func TestAssertSliceFormats(t *testing.T) {
+	os.Setenv("SLICEHOSTS", "a, b")
+	os.Setenv("SLICELINES", "1\n2\n3")
+	os.Setenv("SLICELABELS", `plain,"with, comma"`)
+	defer func() {
+		os.Unsetenv("SLICEHOSTS")
+		os.Unsetenv("SLICELINES")
+		os.Unsetenv("SLICELABELS")
+	}()
+
+	config, err := Assert(TestConfigSliceFormats{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if !equalStringSlices(config.Hosts, []string{"a", "b"}) {
+		t.Errorf("Expected [a b], got %q", config.Hosts)
+	}
+	if len(config.Lines) != 3 || config.Lines[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", config.Lines)
+	}
+	if !equalStringSlices(config.Labels, []string{"plain", "with, comma"}) {
+		t.Errorf("Expected [plain \"with, comma\"], got %q", config.Labels)
+	}
+
+	os.Setenv("SLICELABELS", `"unterminated`)
+	_, err = Assert(TestConfigSliceFormats{})
+	if err == nil || !strings.Contains(err.Error(), "unterminated quote") {
+		t.Errorf("Expected unterminated quote error, got: %v", err)
+	}
+}
+
+func TestSliceElementOptions(t *testing.T) {
+	tests := []struct {
+		name           string
+		value          string
+		options        sliceOptions
+		expected       []string
+		expectedReason string
+	}{
+		{
+			name:     "elements are trimmed",
+			value:    "a, b ,c",
+			options:  sliceOptions{sep: ",", trim: true},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "elements are not trimmed",
+			value:    "a, b",
+			options:  sliceOptions{sep: ","},
+			expected: []string{"a", " b"},
+		},
+		{
+			name:     "trailing separator is kept",
+			value:    "a,b,",
+			options:  sliceOptions{sep: ",", trim: true},
+			expected: []string{"a", "b", ""},
+		},
+		{
+			name:     "empty elements are dropped",
+			value:    "a,, b, ,",
+			options:  sliceOptions{sep: ",", trim: true, omitEmpty: true},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "csv quoted elements keep their whitespace",
+			value:    `a , " b " ,c`,
+			options:  sliceOptions{sep: ",", format: formatCSV, trim: true},
+			expected: []string{"a", " b ", "c"},
+		},
+		{
+			name:           "elements not allowed",
+			value:          "read,delete,write,drop",
+			options:        sliceOptions{sep: ",", values: []string{"read", "write"}},
+			expectedReason: "elements 1, 3 are not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elements, err := splitSlice(tt.value, tt.options)
+			if err == nil && len(tt.options.values) > 0 {
+				err = checkAllowedElements("string", elements, tt.options.values)
+			}
+
+			if tt.expectedReason != "" {
+				if err == nil || err.Error() != tt.expectedReason {
+					t.Errorf("Expected error '%s', got: %v", tt.expectedReason, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if !equalStringSlices(elements, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, elements)
+			}
+		})
+	}
+}
+
+type TestConfigSliceElements struct {
+	Hosts []string `env:"required,separator=',',name='ELEMHOSTS'"`
+	Ports []int    `env:"optional,separator=',',omitempty,unique,name='ELEMPORTS'"`
+	Modes []string `env:"optional,separator=',',values='read,write',name='ELEMMODES'"`
+}
+
+func TestValidateSliceElements(t *testing.T) {
+	tests := []struct {
+		name            string
+		envVars         map[string]string
+		expectedInvalid map[string]string
+	}{
+		{
+			name: "valid elements",
+			envVars: map[string]string{
+				"ELEMHOSTS": "a, b",
+				"ELEMPORTS": "80, 443,",
+				"ELEMMODES": "read, write",
+			},
+		},
+		{
+			name: "duplicated elements",
+			envVars: map[string]string{
+				"ELEMHOSTS": "a",
+				"ELEMPORTS": "80,443,80,080",
+			},
+			expectedInvalid: map[string]string{"Ports (ELEMPORTS)": "elements 2, 3 are duplicated"},
+		},
+		{
+			name: "elements not allowed",
+			envVars: map[string]string{
+				"ELEMHOSTS": "a",
+				"ELEMMODES": "read,delete",
+			},
+			expectedInvalid: map[string]string{"Modes (ELEMMODES)": "element 1 is not allowed"},
+		},
+		{
+			name: "invalid elements",
+			envVars: map[string]string{
+				"ELEMHOSTS": "a",
+				"ELEMPORTS": "80,http",
+			},
+			expectedInvalid: map[string]string{"Ports (ELEMPORTS)": "invalid slice: element 1 is not valid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(TestConfigSliceElements{}, tt.envVars)
+
+			if len(report.Missing) > 0 {
+				t.Fatalf("Expected no missing variables, got %+v", report.Missing)
+			}
+			if len(report.Invalid) != len(tt.expectedInvalid) {
+				t.Fatalf("Expected invalid %v, got %+v", tt.expectedInvalid, report.Invalid)
+			}
+			for _, e := range report.Invalid {
+				if reason, ok := tt.expectedInvalid[e.label()]; !ok || e.Reason != reason {
+					t.Errorf("Unexpected invalid variable %s: %s", e.label(), e.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestUniqueElementsOfStructTypes(t *testing.T) {
+	cert, _ := validCertificate(t)
+	other, _ := validCertificate(t)
+	config := struct {
+		Replicas []DSN         `env:"optional,separator=' ',unique,name='UNIQREPLICAS'"`
+		CAs      []Certificate `env:"optional,separator=';',unique,name='UNIQCAS'"`
+	}{}
+
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		reason  string
+	}{
+		{
+			name:    "DSNs that only differ in password",
+			envVars: map[string]string{"UNIQREPLICAS": "postgres://u:one@h/db postgres://u:two@h/db"},
+		},
+		{
+			name:    "same DSN",
+			envVars: map[string]string{"UNIQREPLICAS": "postgres://u:one@h/db postgres://u:one@h/db"},
+			reason:  "element 1 is duplicated",
+		},
+		{
+			name:    "different certificates",
+			envVars: map[string]string{"UNIQCAS": cert + ";" + other},
+		},
+		{
+			name:    "same certificate",
+			envVars: map[string]string{"UNIQCAS": cert + ";" + cert},
+			reason:  "element 1 is duplicated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(config, tt.envVars)
+			if tt.reason == "" {
+				if !report.Valid() {
+					t.Errorf("Expected a valid report, got:\n%s", report.Render(false))
+				}
+				return
+			}
+			if len(report.Invalid) != 1 || report.Invalid[0].Reason != tt.reason {
+				t.Errorf("Expected reason '%s', got %+v", tt.reason, report.Invalid)
+			}
+		})
+	}
+}
+
+func TestSliceDefaultElementsInValues(t *testing.T) {
+	type valid struct {
+		Modes []string `env:"optional,separator=',',values='read,write',default='read, write',name='DEFMODES'"`
+	}
+	type invalid struct {
+		Modes []string `env:"optional,separator=',',values='read,write',default='read,drop',name='DEFMODES'"`
+	}
+
+	config, err := Assert(valid{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+	if !equalStringSlices(config.Modes, []string{"read", "write"}) {
+		t.Errorf("Expected [read write], got %q", config.Modes)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Expected panic for default element not in allowed values")
+		}
+	}()
+	Assert(invalid{})
+}
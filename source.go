@@ -0,0 +1,189 @@
+package env
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The suffix of the variables that hold the path to a file with the actual
+// value, as used by Docker and Kubernetes secrets. For example, `DB_PASSWORD`
+// is read from the file in `DB_PASSWORD_FILE`.
+const fileSuffix = "_FILE"
+
+// Resolves the values of the environment variables for a single validation
+// run. Dotenv files are read when the source is created, so that every run
+// sees their current content.
+type source struct {
+	// Replaces the environment of the process when set
+	environ map[string]string
+
+	dotenv      map[string]string
+	fileSecrets bool
+
+	// The dotenv file each variable was read from
+	dotenvFiles map[string]string
+
+	// The profile that selects the defaults, read from profileVar unless it
+	// is given
+	profile         string
+	profileVar      string
+	profileResolved bool
+
+	// Whether defaults and unset optional fields are rejected, see WithStrict.
+	// Read from ENV_STRICT unless it is enabled with the option.
+	strict         bool
+	strictResolved bool
+	strictErr      *FieldError
+
+	// The words accepted as booleans, unless a field has its own
+	bools BoolVocabulary
+
+	// Whether validating must leave the filesystem as it is, when the values
+	// are only checked and not used, see ValidateMap and Explain
+	dryRun bool
+
+	// The files the values were read from, so that they can be watched
+	files []string
+
+	// The variables that were looked up, to find the unknown ones
+	read map[string]bool
+}
+
+// Returns a source that only reads the environment of the process
+func osSource() *source {
+	return &source{profileVar: defaultProfileVar, read: make(map[string]bool)}
+}
+
+// Returns a source that reads the given variables instead of the environment
+// of the process
+func mapSource(variables map[string]string) *source {
+	return &source{environ: variables, profileVar: defaultProfileVar, read: make(map[string]bool), dryRun: true}
+}
+
+func newSource(o *options) (*source, error) {
+	s := &source{
+		dotenv:      make(map[string]string),
+		dotenvFiles: make(map[string]string),
+		fileSecrets: o.fileSecrets,
+		profile:     o.profile,
+		profileVar:  o.profileVar,
+		strict:      o.strict,
+		bools:       o.bools,
+		read:        make(map[string]bool),
+	}
+
+	// Later files take precedence over earlier ones
+	for _, path := range o.dotenv {
+		values, err := readDotenv(path)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			s.dotenv[key] = value
+			s.dotenvFiles[key] = path
+		}
+		s.files = append(s.files, path)
+	}
+
+	return s, nil
+}
+
+// Returns the value of the environment variable and where it was read from.
+// The environment of the process takes precedence over secret files, and these
+// over dotenv files.
+func (s *source) lookup(name string) (string, Origin, error) {
+	s.read[name] = true
+	if value := s.getenv(name); value != "" {
+		return value, Origin{Source: SourceEnvironment}, nil
+	}
+
+	if s.fileSecrets {
+		s.read[name+fileSuffix] = true
+		path := s.getenv(name + fileSuffix)
+		if path == "" {
+			path = s.dotenv[name+fileSuffix]
+		}
+		if path != "" {
+			origin := Origin{Source: SourceFile, Path: path}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return "", origin, fmt.Errorf("cannot read %s%s: %v", name, fileSuffix, err)
+			}
+			s.files = append(s.files, path)
+			return strings.TrimRight(string(content), "\r\n"), origin, nil
+		}
+	}
+
+	if value := s.dotenv[name]; value != "" {
+		return value, Origin{Source: SourceDotenv, Path: s.dotenvFiles[name]}, nil
+	}
+	return "", Origin{Source: SourceUnset}, nil
+}
+
+func (s *source) getenv(name string) string {
+	if s.environ != nil {
+		return s.environ[name]
+	}
+	return os.Getenv(name)
+}
+
+func readDotenv(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return values, nil
+}
+
+// ParseDotenv parses `KEY=VALUE` lines. Blank lines and lines starting with
+// `#` are ignored, and so is an `export` in front of the key. Values may be
+// quoted: double quotes support escape sequences like `\n`, single quotes are
+// taken literally. Unquoted values end at the first ` #`.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", n)
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value: %v", n, err)
+			}
+			value = unquoted
+		case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+
+		values[key] = value
+	}
+
+	return values, scanner.Err()
+}
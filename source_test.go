@@ -0,0 +1,165 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "simple values",
+			input:    "HOST=localhost\nPORT=8080\n",
+			expected: map[string]string{"HOST": "localhost", "PORT": "8080"},
+		},
+		{
+			name:     "comments and blank lines",
+			input:    "# database\n\nHOST=localhost\n  # indented comment\n",
+			expected: map[string]string{"HOST": "localhost"},
+		},
+		{
+			name:     "export prefix",
+			input:    "export HOST=localhost",
+			expected: map[string]string{"HOST": "localhost"},
+		},
+		{
+			name:     "double quoted value with escapes",
+			input:    `GREETING="hello\nworld"`,
+			expected: map[string]string{"GREETING": "hello\nworld"},
+		},
+		{
+			name:     "single quoted value is literal",
+			input:    `PATTERN='a\nb # c'`,
+			expected: map[string]string{"PATTERN": `a\nb # c`},
+		},
+		{
+			name:     "inline comment",
+			input:    "PORT=8080 # the port",
+			expected: map[string]string{"PORT": "8080"},
+		},
+		{
+			name:     "empty value",
+			input:    "HOST=",
+			expected: map[string]string{"HOST": ""},
+		},
+		{
+			name:     "value with equal sign",
+			input:    "DSN=postgres://host/db?sslmode=disable",
+			expected: map[string]string{"DSN": "postgres://host/db?sslmode=disable"},
+		},
+		{
+			name:        "missing equal sign",
+			input:       "HOST",
+			expectError: true,
+		},
+		{
+			name:        "missing key",
+			input:       "=value",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDotenv(strings.NewReader(tt.input))
+
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error for input '%s' but got none", tt.input)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error for input '%s' but got: %v", tt.input, err)
+			}
+			if tt.expectError {
+				return
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Errorf("Expected %d values, got %d: %v", len(tt.expected), len(result), result)
+			}
+			for key, value := range tt.expected {
+				if result[key] != value {
+					t.Errorf("Expected %s='%s', got '%s'", key, value, result[key])
+				}
+			}
+		})
+	}
+}
+
+func TestSourcePrecedence(t *testing.T) {
+	dir := t.TempDir()
+	dotenv := filepath.Join(dir, ".env")
+	secret := filepath.Join(dir, "password")
+	os.WriteFile(dotenv, []byte("SRCHOST=dotenv\nSRCPORT=8080\nSRCPASSWORD=dotenv\n"), 0o600)
+	os.WriteFile(secret, []byte("s3cr3t\n"), 0o600)
+
+	os.Setenv("SRCHOST", "environment")
+	os.Setenv("SRCPASSWORD_FILE", secret)
+	defer func() {
+		os.Unsetenv("SRCHOST")
+		os.Unsetenv("SRCPASSWORD_FILE")
+	}()
+
+	src, err := newSource(newOptions([]Option{WithDotenv(dotenv), WithFileSecrets()}))
+	if err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	expected := map[string]string{
+		"SRCHOST":     "environment",
+		"SRCPORT":     "8080",
+		"SRCPASSWORD": "s3cr3t",
+		"SRCMISSING":  "",
+	}
+	for name, value := range expected {
+		result, _, err := src.lookup(name)
+		if err != nil {
+			t.Errorf("Expected no error for '%s' but got: %v", name, err)
+		}
+		if result != value {
+			t.Errorf("Expected %s='%s', got '%s'", name, value, result)
+		}
+	}
+
+	if len(src.files) != 2 {
+		t.Errorf("Expected 2 files read, got %v", src.files)
+	}
+}
+
+func TestSourceMissingFiles(t *testing.T) {
+	_, err := newSource(newOptions([]Option{WithDotenv("/nonexistent/.env")}))
+	if err == nil {
+		t.Errorf("Expected error for missing dotenv file but got none")
+	}
+
+	os.Setenv("SRCTOKEN_FILE", "/nonexistent/token")
+	defer os.Unsetenv("SRCTOKEN_FILE")
+
+	src, _ := newSource(newOptions([]Option{WithFileSecrets()}))
+	if _, _, err := src.lookup("SRCTOKEN"); err == nil {
+		t.Errorf("Expected error for missing secret file but got none")
+	}
+}
+
+func TestAssertWithDotenv(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(dotenv, []byte("DATABASEURL=postgres://localhost/db\nPORT=9090\n"), 0o600)
+
+	config, err := Assert(TestConfig{}, WithDotenv(dotenv))
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	if config.DatabaseURL != "postgres://localhost/db" {
+		t.Errorf("Expected 'postgres://localhost/db', got '%s'", config.DatabaseURL)
+	}
+	if config.Port != 9090 {
+		t.Errorf("Expected 9090, got %d", config.Port)
+	}
+}
@@ -0,0 +1,47 @@
+package env
+
+import "fmt"
+
+// The variable that enables strict mode when WithStrict is not given
+const strictVar = "ENV_STRICT"
+
+// Reports whether strict mode is enabled, either with WithStrict or with the
+// ENV_STRICT variable
+func (s *source) strictMode() bool {
+	s.resolveStrict()
+	return s.strict
+}
+
+// Reads ENV_STRICT, once, with the words of BoolExtended. A value that is not
+// a boolean is reported as invalid rather than guessed, and leaves strict mode
+// as set by WithStrict.
+func (s *source) resolveStrict() *FieldError {
+	if !s.strictResolved {
+		s.strictResolved = true
+		if s.strict {
+			return nil
+		}
+
+		value, _, readErr := s.lookup(strictVar)
+		if readErr != nil {
+			s.strictErr = &FieldError{Variable: strictVar, Type: "bool", Reason: readErr.Error()}
+			return s.strictErr
+		}
+		if value == "" {
+			return nil
+		}
+
+		enabled, err := BoolExtended.parse(value)
+		if err != nil {
+			s.strictErr = &FieldError{Variable: strictVar, Type: "bool", Value: value, Reason: fmt.Sprint(err)}
+			return s.strictErr
+		}
+		s.strict = enabled
+	}
+	return s.strictErr
+}
+
+// Reports whether strict mode applies to the field
+func (s *source) strictFor(tag string) bool {
+	return !isNoStrict(tag) && s.strictMode()
+}
@@ -0,0 +1,151 @@
+package env
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+type TestConfigStrict struct {
+	Host    string `env:"required,name='STRICTHOST'"`
+	Port    int    `env:"optional,default='8080',name='STRICTPORT'"`
+	Debug   bool   `env:"optional,name='STRICTDEBUG'"`
+	Workers int    `env:"optional,default='4',nostrict,name='STRICTWORKERS'"`
+	Token   string `env:"optional,nostrict,name='STRICTTOKEN'"`
+}
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []Option
+		envVars         map[string]string
+		expectedMissing []string
+	}{
+		{
+			name:    "not strict",
+			envVars: map[string]string{"STRICTHOST": "localhost"},
+		},
+		{
+			name:    "strict from option",
+			opts:    []Option{WithStrict()},
+			envVars: map[string]string{"STRICTHOST": "localhost"},
+			expectedMissing: []string{
+				"Port (STRICTPORT): defaults are not allowed in strict mode",
+				"Debug (STRICTDEBUG): optional fields must be set in strict mode",
+			},
+		},
+		{
+			name:    "strict from ENV_STRICT",
+			envVars: map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "1"},
+			expectedMissing: []string{
+				"Port (STRICTPORT): defaults are not allowed in strict mode",
+				"Debug (STRICTDEBUG): optional fields must be set in strict mode",
+			},
+		},
+		{
+			name:    "ENV_STRICT disabled",
+			envVars: map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "false"},
+		},
+		{
+			name:    "ENV_STRICT disabled with no",
+			envVars: map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "no"},
+		},
+		{
+			name:    "ENV_STRICT disabled with off",
+			envVars: map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "OFF"},
+		},
+		{
+			name:    "strict from ENV_STRICT=on",
+			envVars: map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "on"},
+			expectedMissing: []string{
+				"Port (STRICTPORT): defaults are not allowed in strict mode",
+				"Debug (STRICTDEBUG): optional fields must be set in strict mode",
+			},
+		},
+		{
+			name:    "strict with every value set",
+			opts:    []Option{WithStrict()},
+			envVars: map[string]string{"STRICTHOST": "localhost", "STRICTPORT": "80", "STRICTDEBUG": "false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.envVars {
+					os.Unsetenv(key)
+				}
+			}()
+
+			_, err := Assert(TestConfigStrict{}, tt.opts...)
+
+			if tt.expectedMissing == nil {
+				if err != nil {
+					t.Errorf("Expected no error but got: %v", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			missing, _ := err.(*ValidationError).legacy()
+			if len(missing) != len(tt.expectedMissing) {
+				t.Fatalf("Expected missing %v, got %v", tt.expectedMissing, missing)
+			}
+			for i, expected := range tt.expectedMissing {
+				if missing[i] != expected {
+					t.Errorf("Expected missing '%s', got '%s'", expected, missing[i])
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidStrictVar(t *testing.T) {
+	report := ValidateMap(TestConfigStrict{}, map[string]string{"STRICTHOST": "localhost", "ENV_STRICT": "maybe"})
+	if len(report.Missing) != 0 {
+		t.Errorf("Expected strict mode to stay disabled, got missing %+v", report.Missing)
+	}
+	if len(report.Invalid) != 1 || report.Invalid[0].label() != "ENV_STRICT" || report.Invalid[0].Value != "maybe" {
+		t.Fatalf("Expected ENV_STRICT to be invalid, got %+v", report.Invalid)
+	}
+	if !strings.Contains(report.Invalid[0].Reason, "expected one of: true, false") {
+		t.Errorf("Expected the accepted words in the reason, got '%s'", report.Invalid[0].Reason)
+	}
+	if len(report.Unknown) != 0 {
+		t.Errorf("Expected ENV_STRICT not to be unknown, got %v", report.Unknown)
+	}
+}
+
+func TestStrictModeExclusiveGroup(t *testing.T) {
+	config := struct {
+		Token    string `env:"optional,exclusive='auth',name='STRICTTOKEN'"`
+		Password string `env:"optional,exclusive='auth',name='STRICTPASSWORD'"`
+	}{}
+
+	tests := []struct {
+		name    string
+		envVars map[string]string
+		valid   bool
+	}{
+		{name: "one of the group", envVars: map[string]string{"ENV_STRICT": "1", "STRICTTOKEN": "abc"}, valid: true},
+		{name: "none of the group", envVars: map[string]string{"ENV_STRICT": "1"}, valid: true},
+		{name: "both of the group", envVars: map[string]string{"ENV_STRICT": "1", "STRICTTOKEN": "abc", "STRICTPASSWORD": "secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ValidateMap(config, tt.envVars)
+			if len(report.Missing) != 0 {
+				t.Errorf("Expected no missing fields, got %+v", report.Missing)
+			}
+			if report.Valid() != tt.valid {
+				t.Errorf("Expected valid to be %v, got:\n%s", tt.valid, report.Render(false))
+			}
+		})
+	}
+}
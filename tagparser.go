@@ -0,0 +1,261 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The options a tag may have, and whether they take a value. Defaults for
+// profiles, e.g. `default.dev`, are handled apart since the profile can be any
+// name.
+var knownOptions = map[string]bool{
+	"required":       false,
+	"optional":       false,
+	"static":         false,
+	"secret":         false,
+	"nostrict":       false,
+	"notrim":         false,
+	"omitempty":      false,
+	"unique":         false,
+	"exists":         false,
+	"readable":       false,
+	"writable":       false,
+	"executable":     false,
+	"create":         false,
+	"abs":            false,
+	"trim":           false,
+	"unquote":        false,
+	"lower":          false,
+	"upper":          false,
+	"casefold":       false,
+	"units":          false,
+	"default":        true,
+	"defaultport":    true,
+	"san":            true,
+	"driver":         true,
+	"bool":           true,
+	"min":            true,
+	"max":            true,
+	"values":         true,
+	"name":           true,
+	"separator":      true,
+	"format":         true,
+	"prefix":         true,
+	"desc":           true,
+	"requiredif":     true,
+	"requiredunless": true,
+	"oneof":          true,
+	"exclusive":      true,
+}
+
+// The prefix of the options that set the default of a profile
+const profileDefaultPrefix = "default."
+
+// Options that can't be used together, and why
+var contradictions = []struct {
+	first, second string
+	reason        string
+}{
+	{"required", "optional", "a field can't be both required and optional"},
+	{"required", "default", "the default of a required field is never used"},
+	{"required", "requiredif", "a required field can't be conditionally required"},
+	{"required", "requiredunless", "a required field can't be conditionally required"},
+	{"required", "oneof", "a required field can't be part of a oneof group"},
+	{"lower", "upper", "a value can't be both lowercase and uppercase"},
+	{"trim", "notrim", "a value can't be both trimmed and not trimmed"},
+}
+
+// An option of a struct tag, e.g. `default='8080'` or `optional`
+type tagOption struct {
+	value    string
+	hasValue bool
+}
+
+// The options of a struct tag, keyed by their lowercase name
+type tagOptions map[string]tagOption
+
+// Tags are parsed once, struct tags never change
+var parsedTags sync.Map
+
+// Returns the options of the tag. An invalid tag is a programming error, so
+// it panics as soon as the struct is used.
+func parseTag(tag string) tagOptions {
+	if options, ok := parsedTags.Load(tag); ok {
+		return options.(tagOptions)
+	}
+
+	options, err := parseTagOptions(tag)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid tag `%s`: %v", tag, err))
+	}
+
+	parsedTags.Store(tag, options)
+	return options
+}
+
+// Parses a comma separated list of options. Options are either a bare key,
+// like `optional`, or a key and a value in single quotes, like `name='PORT'`.
+// Within quotes `\'` is a quote, `\\` a backslash and `\n`, `\r` and `\t` are
+// a newline, carriage return and tab. Any other backslash is kept as is.
+func parseTagOptions(tag string) (tagOptions, error) {
+	options := make(tagOptions)
+	p := &tagParser{input: tag}
+
+	p.skipSpaces()
+	for !p.done() {
+		key, option, err := p.option()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOption(key, option); err != nil {
+			return nil, err
+		}
+		if _, ok := options[key]; ok {
+			return nil, fmt.Errorf("duplicate option '%s'", key)
+		}
+		options[key] = option
+
+		p.skipSpaces()
+		if p.done() {
+			break
+		}
+		if !p.consume(',') {
+			return nil, fmt.Errorf("expected ',' after option '%s' at position %d", key, p.pos)
+		}
+		p.skipSpaces()
+		if p.done() {
+			return nil, fmt.Errorf("expected an option after ',' at position %d", p.pos)
+		}
+	}
+
+	return options, checkContradictions(options)
+}
+
+// Checks that the option is known and has a value if and only if it takes one.
+// A default for the `prod` profile would never be used, see defaultForbidden.
+func checkOption(key string, option tagOption) error {
+	takesValue, known := knownOptions[key]
+	if profile, ok := strings.CutPrefix(key, profileDefaultPrefix); ok && profile != "" {
+		takesValue, known = true, true
+		if profile == ProfileProduction && option.value != "" {
+			return fmt.Errorf("option '%s' is never used, defaults are not allowed in profile '%s'", key, profile)
+		}
+	}
+
+	switch {
+	case !known:
+		return fmt.Errorf("unknown option '%s', expected one of: %s", key, strings.Join(optionNames(), ", "))
+	case takesValue && !option.hasValue:
+		return fmt.Errorf("option '%s' requires a value, e.g. %s='...'", key, key)
+	case !takesValue && option.hasValue:
+		return fmt.Errorf("option '%s' does not take a value", key)
+	}
+	return nil
+}
+
+func checkContradictions(options tagOptions) error {
+	for _, c := range contradictions {
+		_, first := options[c.first]
+		_, second := options[c.second]
+		if first && second {
+			return fmt.Errorf("'%s' contradicts '%s': %s", c.first, c.second, c.reason)
+		}
+	}
+	return nil
+}
+
+// Returns the names of the known options, sorted
+func optionNames() []string {
+	names := make([]string, 0, len(knownOptions)+1)
+	for name := range knownOptions {
+		names = append(names, name)
+	}
+	names = append(names, profileDefaultPrefix+"PROFILE")
+	sort.Strings(names)
+	return names
+}
+
+type tagParser struct {
+	input string
+	pos   int
+}
+
+func (p *tagParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *tagParser) peek() byte {
+	return p.input[p.pos]
+}
+
+func (p *tagParser) consume(c byte) bool {
+	if !p.done() && p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *tagParser) skipSpaces() {
+	for !p.done() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// Parses a single option, returning its lowercase key
+func (p *tagParser) option() (string, tagOption, error) {
+	start := p.pos
+	for !p.done() && isKeyChar(p.peek()) {
+		p.pos++
+	}
+	key := toLower(p.input[start:p.pos])
+	if key == "" {
+		return "", tagOption{}, fmt.Errorf("expected an option at position %d", p.pos)
+	}
+
+	p.skipSpaces()
+	if !p.consume('=') {
+		return key, tagOption{}, nil
+	}
+
+	p.skipSpaces()
+	value, err := p.quoted(key)
+	if err != nil {
+		return "", tagOption{}, err
+	}
+	return key, tagOption{value: value, hasValue: true}, nil
+}
+
+// The escape sequences of quoted values, by the character after the backslash
+var tagEscapes = map[byte]byte{'\'': '\'', '\\': '\\', 'n': '\n', 'r': '\r', 't': '\t'}
+
+// Parses a value in single quotes
+func (p *tagParser) quoted(key string) (string, error) {
+	if !p.consume('\'') {
+		return "", fmt.Errorf("the value of '%s' must be in single quotes, e.g. %s='...'", key, key)
+	}
+
+	var b strings.Builder
+	for !p.done() {
+		c := p.peek()
+		p.pos++
+
+		switch {
+		case c == '\'':
+			return b.String(), nil
+		case c == '\\' && !p.done() && tagEscapes[p.peek()] != 0:
+			b.WriteByte(tagEscapes[p.peek()])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated value of '%s'", key)
+}
+
+func isKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
@@ -0,0 +1,217 @@
+package env
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTagOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected tagOptions
+	}{
+		{
+			name:     "empty tag",
+			tag:      "",
+			expected: tagOptions{},
+		},
+		{
+			name: "flags and values",
+			tag:  "optional,default='8080',name='PORT'",
+			expected: tagOptions{
+				"optional": {},
+				"default":  {value: "8080", hasValue: true},
+				"name":     {value: "PORT", hasValue: true},
+			},
+		},
+		{
+			name: "spaces around options",
+			tag:  " optional , default = 'a b' ",
+			expected: tagOptions{
+				"optional": {},
+				"default":  {value: "a b", hasValue: true},
+			},
+		},
+		{
+			name: "keys are case insensitive",
+			tag:  "OPTIONAL,Default.Dev='x'",
+			expected: tagOptions{
+				"optional":    {},
+				"default.dev": {value: "x", hasValue: true},
+			},
+		},
+		{
+			name: "commas and equal signs in values",
+			tag:  "values='a,b',requiredif='Mode=remote'",
+			expected: tagOptions{
+				"values":     {value: "a,b", hasValue: true},
+				"requiredif": {value: "Mode=remote", hasValue: true},
+			},
+		},
+		{
+			name: "escaped quote and backslash",
+			tag:  `default='it\'s \\ fine'`,
+			expected: tagOptions{
+				"default": {value: `it's \ fine`, hasValue: true},
+			},
+		},
+		{
+			name: "escaped newline and tab",
+			tag:  `separator='\n\t'`,
+			expected: tagOptions{
+				"separator": {value: "\n\t", hasValue: true},
+			},
+		},
+		{
+			name: "other backslashes are kept",
+			tag:  `default='C:\dir'`,
+			expected: tagOptions{
+				"default": {value: `C:\dir`, hasValue: true},
+			},
+		},
+		{
+			name: "option names inside values",
+			tag:  "desc='optional, secret and static'",
+			expected: tagOptions{
+				"desc": {value: "optional, secret and static", hasValue: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTagOptions(tt.tag)
+			if err != nil {
+				t.Fatalf("Expected no error for tag '%s' but got: %v", tt.tag, err)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("Expected %v, got %v", tt.expected, result)
+			}
+			for key, option := range tt.expected {
+				if result[key] != option {
+					t.Errorf("Expected %s=%+v, got %+v", key, option, result[key])
+				}
+			}
+		})
+	}
+}
+
+func TestParseTagOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected string
+	}{
+		{
+			name:     "unknown option",
+			tag:      "requred",
+			expected: "unknown option 'requred'",
+		},
+		{
+			name:     "word containing an option",
+			tag:      "optionality",
+			expected: "unknown option 'optionality'",
+		},
+		{
+			name:     "duplicate option",
+			tag:      "optional,name='A',name='B'",
+			expected: "duplicate option 'name'",
+		},
+		{
+			name:     "duplicate profile default",
+			tag:      "default.dev='a',default.DEV='b'",
+			expected: "duplicate option 'default.dev'",
+		},
+		{
+			name:     "required and optional",
+			tag:      "required,optional",
+			expected: "'required' contradicts 'optional'",
+		},
+		{
+			name:     "required with default",
+			tag:      "required,default='x'",
+			expected: "'required' contradicts 'default'",
+		},
+		{
+			name:     "required and conditional",
+			tag:      "required,requiredif='A'",
+			expected: "'required' contradicts 'requiredif'",
+		},
+		{
+			name:     "unquoted value",
+			tag:      "default=8080",
+			expected: "must be in single quotes",
+		},
+		{
+			name:     "double quoted value",
+			tag:      `default="8080"`,
+			expected: "must be in single quotes",
+		},
+		{
+			name:     "unterminated value",
+			tag:      "default='8080",
+			expected: "unterminated value of 'default'",
+		},
+		{
+			name:     "flag with a value",
+			tag:      "optional='true'",
+			expected: "option 'optional' does not take a value",
+		},
+		{
+			name:     "option without a value",
+			tag:      "optional,default",
+			expected: "option 'default' requires a value",
+		},
+		{
+			name:     "profile default without profile",
+			tag:      "default.='x'",
+			expected: "unknown option 'default.'",
+		},
+		{
+			name:     "default for the prod profile",
+			tag:      "optional,default.PROD='x'",
+			expected: "option 'default.prod' is never used",
+		},
+		{
+			name:     "trailing comma",
+			tag:      "required,",
+			expected: "expected an option after ','",
+		},
+		{
+			name:     "missing comma",
+			tag:      "optional default='x'",
+			expected: "expected ',' after option 'optional'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTagOptions(tt.tag)
+			if err == nil {
+				t.Fatalf("Expected error for tag '%s' but got none", tt.tag)
+			}
+			if !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing '%s', got '%v'", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestInvalidTagPanicsAtDefinition(t *testing.T) {
+	type config struct {
+		Port int `env:"requred"`
+	}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatalf("Expected panic for unknown option")
+		}
+		if !strings.Contains(r.(string), "unknown option 'requred'") {
+			t.Errorf("Expected the unknown option in the panic, got: %v", r)
+		}
+	}()
+
+	Describe(config{})
+}
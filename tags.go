@@ -1,82 +1,260 @@
 package env
 
 import (
-	"regexp"
+	"fmt"
 	"strings"
 )
 
 const defaultSeparator = " "
 
-var (
-	defaultRegex   = regexp.MustCompile("default='(?P<Default>.*?)'")
-	separatorRegex = regexp.MustCompile("separator='(?P<Sep>.)'")
-	nameRegex      = regexp.MustCompile("name='(?P<Name>.*?)'")
-	valuesRegex    = regexp.MustCompile("values='(?P<Values>.*?)'")
-)
+// A condition on the value of another field, as used by `requiredif` and
+// `requiredunless`. If value is not set the condition only checks that the
+// field has a value.
+type condition struct {
+	field    string
+	value    string
+	hasValue bool
+}
+
+func (c condition) String() string {
+	if !c.hasValue {
+		return c.field + " is set"
+	}
+	return c.field + "=" + c.value
+}
 
 func toLower(tag string) string {
 	return strings.ToLower(tag)
 }
 
+// Reports whether the tag has the option, with or without a value
+func (o tagOptions) has(key string) bool {
+	_, ok := o[key]
+	return ok
+}
+
+// Returns the value of the option and whether the tag has it
+func (o tagOptions) get(key string) (string, bool) {
+	option, ok := o[key]
+	return option.value, ok
+}
+
 func isOptional(tag string) bool {
-	return strings.Contains(toLower(tag), "optional")
+	return parseTag(tag).has("optional")
+}
+
+// Static fields are read once at startup and must not change at runtime
+func isStatic(tag string) bool {
+	return parseTag(tag).has("static")
+}
+
+// Fields that opt out of strict mode may keep using defaults or be left unset
+func isNoStrict(tag string) bool {
+	return parseTag(tag).has("nostrict")
+}
+
+// Elements of slices are trimmed unless the field opts out with `notrim`
+func isNoTrim(tag string) bool {
+	return parseTag(tag).has("notrim")
+}
+
+// Empty elements of slices are dropped, e.g. after a trailing separator
+func isOmitEmpty(tag string) bool {
+	return parseTag(tag).has("omitempty")
+}
+
+// Slices must not have repeated elements
+func isUnique(tag string) bool {
+	return parseTag(tag).has("unique")
+}
+
+// Secret values are never written to generated files or shown in reports
+func isSecret(tag string) bool {
+	return parseTag(tag).has("secret")
 }
 
 func hasDefault(tag string) bool {
-	m := defaultRegex.FindAllStringSubmatch(tag, -1)
-	return len(m) > 0
+	return parseTag(tag).has("default")
 }
 
 func getDefault(tag string) string {
-	m := defaultRegex.FindAllStringSubmatch(tag, -1)
-	return m[0][1]
+	value, _ := parseTag(tag).get("default")
+	return value
 }
 
-func getSeparator(tag string) string {
-	m := separatorRegex.FindAllStringSubmatch(tag, -1)
+// Returns the defaults for specific profiles, e.g. `default.dev='localhost'`,
+// keyed by the lowercase name of the profile
+func getProfileDefaults(tag string) map[string]string {
+	var defaults map[string]string
+	for key, option := range parseTag(tag) {
+		if profile, ok := strings.CutPrefix(key, profileDefaultPrefix); ok {
+			if defaults == nil {
+				defaults = make(map[string]string)
+			}
+			defaults[profile] = option.value
+		}
+	}
+	return defaults
+}
 
-	if len(m) == 0 {
+// Returns the separator of the elements of a slice. Separators may have
+// several characters and escape sequences like `\n`, see parseTagOptions. CSV
+// lists are separated by commas unless a separator is given.
+func getSeparator(tag string) string {
+	sep, ok := parseTag(tag).get("separator")
+	if !ok {
+		if getFormat(tag) == formatCSV {
+			return ","
+		}
 		return defaultSeparator
 	}
 
-	if len(m) != 1 {
-		panic("Too many separators in tag")
+	if sep == "" {
+		panic("Invalid separator in tag, it must not be empty")
 	}
 
-	return m[0][1]
+	return sep
 }
 
-func getName(tag string) string {
-	m := nameRegex.FindAllStringSubmatch(tag, -1)
+// Returns the format of the elements of a slice, either empty or `csv`
+func getFormat(tag string) string {
+	format, _ := parseTag(tag).get("format")
+	format = toLower(format)
 
-	if len(m) == 0 {
-		return ""
+	if format != "" && format != formatCSV {
+		panic(fmt.Sprintf("Unknown format '%s' in tag, expected '%s'", format, formatCSV))
 	}
 
-	if len(m) != 1 {
-		panic("Too many name specifications in tag")
+	return format
+}
+
+// Returns the checks of path fields, e.g. `exists,readable`
+func getPathOptions(tag string) pathOptions {
+	options := parseTag(tag)
+	return pathOptions{
+		exists:     options.has("exists"),
+		readable:   options.has("readable"),
+		writable:   options.has("writable"),
+		executable: options.has("executable"),
+		create:     options.has("create"),
+		abs:        options.has("abs"),
+	}
+}
+
+// Returns the names a certificate must be valid for, e.g.
+// `san='api.example.com,10.0.0.1'`
+func getSANs(tag string) []string {
+	sans, ok := parseTag(tag).get("san")
+	if !ok {
+		return nil
 	}
 
-	return m[0][1]
+	names := strings.Split(sans, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return names
 }
 
-func hasValues(tag string) bool {
-	m := valuesRegex.FindAllStringSubmatch(tag, -1)
-	return len(m) > 0
+// Returns the transforms applied to the values of the field, e.g. `lower`
+func getTransforms(tag string) transforms {
+	options := parseTag(tag)
+	return transforms{
+		trim:     options.has("trim"),
+		unquote:  options.has("unquote"),
+		lower:    options.has("lower"),
+		upper:    options.has("upper"),
+		casefold: options.has("casefold"),
+	}
 }
 
-func getValues(tag string) []string {
-	m := valuesRegex.FindAllStringSubmatch(tag, -1)
+// Returns the words accepted by a boolean field, e.g. `bool='extended'`
+func getBoolVocabulary(tag string) (BoolVocabulary, bool) {
+	name, ok := parseTag(tag).get("bool")
+	if !ok {
+		return BoolStandard, false
+	}
 
-	if len(m) == 0 {
+	vocabulary, known := boolVocabularies[toLower(name)]
+	if !known {
+		panic(fmt.Sprintf("Unknown boolean vocabulary '%s' in tag, expected standard, extended or strict", name))
+	}
+	return vocabulary, true
+}
+
+// Returns the drivers a DSN may use, e.g. `driver='postgres|mysql'`
+func getDrivers(tag string) []string {
+	drivers, ok := parseTag(tag).get("driver")
+	if !ok {
 		return nil
 	}
 
-	if len(m) != 1 {
-		panic("Too many values specifications in tag")
+	return strings.FieldsFunc(toLower(drivers), func(r rune) bool {
+		return r == '|' || r == ',' || r == ' '
+	})
+}
+
+// Returns the port of HostPort fields given without one, e.g.
+// `defaultport='5432'`
+func getDefaultPort(tag string) (string, bool) {
+	port, ok := parseTag(tag).get("defaultport")
+	if !ok {
+		return "", false
+	}
+
+	if _, err := parsePort(port); err != nil {
+		panic(fmt.Sprintf("Invalid defaultport in tag: %v", err))
+	}
+
+	return port, true
+}
+
+// Returns whether an int field accepts digit separators and SI suffixes,
+// e.g. `10_000` or `10k`
+func hasUnits(tag string) bool {
+	return parseTag(tag).has("units")
+}
+
+// Returns the lower bound of a numeric field, e.g. `min='1'`
+func getMin(tag string) string {
+	value, _ := parseTag(tag).get("min")
+	return value
+}
+
+// Returns the upper bound of a numeric field, e.g. `max='100%'`
+func getMax(tag string) string {
+	value, _ := parseTag(tag).get("max")
+	return value
+}
+
+func getName(tag string) string {
+	name, _ := parseTag(tag).get("name")
+	return name
+}
+
+// Returns the prefix for the environment variables of a nested struct. An
+// empty prefix is valid and means the fields are read without one.
+func getPrefix(tag string) (string, bool) {
+	return parseTag(tag).get("prefix")
+}
+
+// Returns the human readable description of the variable, used when
+// generating documentation
+func getDescription(tag string) string {
+	desc, _ := parseTag(tag).get("desc")
+	return desc
+}
+
+func hasValues(tag string) bool {
+	return parseTag(tag).has("values")
+}
+
+func getValues(tag string) []string {
+	valuesStr, ok := parseTag(tag).get("values")
+	if !ok {
+		return nil
 	}
 
-	valuesStr := m[0][1]
 	// Split by comma and trim whitespace
 	values := strings.Split(valuesStr, ",")
 	for i, v := range values {
@@ -85,3 +263,47 @@ func getValues(tag string) []string {
 
 	return values
 }
+
+func getCondition(tag string, option string) (condition, bool) {
+	value, ok := parseTag(tag).get(option)
+	if !ok {
+		return condition{}, false
+	}
+
+	field, expected, hasValue := strings.Cut(value, "=")
+	field = strings.TrimSpace(field)
+	if field == "" {
+		panic(fmt.Sprintf("Missing field name in %s='%s'", option, value))
+	}
+
+	return condition{field, strings.TrimSpace(expected), hasValue}, true
+}
+
+func getRequiredIf(tag string) (condition, bool) {
+	return getCondition(tag, "requiredif")
+}
+
+func getRequiredUnless(tag string) (condition, bool) {
+	return getCondition(tag, "requiredunless")
+}
+
+func getGroup(tag string, option string) string {
+	group, _ := parseTag(tag).get(option)
+	return strings.TrimSpace(group)
+}
+
+func getOneOf(tag string) string {
+	return getGroup(tag, "oneof")
+}
+
+func getExclusive(tag string) string {
+	return getGroup(tag, "exclusive")
+}
+
+// Conditional fields are only required when their condition is met, so they
+// must not be reported as missing before all the fields have been read.
+func isConditional(tag string) bool {
+	_, requiredIf := getRequiredIf(tag)
+	_, requiredUnless := getRequiredUnless(tag)
+	return requiredIf || requiredUnless || getOneOf(tag) != ""
+}
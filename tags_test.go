@@ -80,9 +80,14 @@ func TestIsOptional(t *testing.T) {
 		},
 		{
 			name:     "contains optional at end",
-			tag:      "required,optional",
+			tag:      "name='PORT',optional",
 			expected: true,
 		},
+		{
+			name:     "optional inside a value",
+			tag:      "required,desc='optional'",
+			expected: false,
+		},
 		{
 			name:     "does not contain optional",
 			tag:      "required",
@@ -93,11 +98,6 @@ func TestIsOptional(t *testing.T) {
 			tag:      "",
 			expected: false,
 		},
-		{
-			name:     "contains optional in word",
-			tag:      "optionality",
-			expected: true,
-		},
 	}
 
 	for _, tt := range tests {
@@ -146,16 +146,6 @@ func TestHasDefault(t *testing.T) {
 			tag:      "",
 			expected: false,
 		},
-		{
-			name:     "default without quotes",
-			tag:      "default=test",
-			expected: false,
-		},
-		{
-			name:     "default with double quotes",
-			tag:      `default="test"`,
-			expected: false,
-		},
 		{
 			name:     "default with empty value",
 			tag:      "default=''",
@@ -285,7 +275,32 @@ func TestGetSeparator(t *testing.T) {
 		{
 			name:     "separator with newline",
 			tag:      "separator='\n'",
-			expected: " ",
+			expected: "\n",
+		},
+		{
+			name:     "separator with several characters",
+			tag:      "separator=', '",
+			expected: ", ",
+		},
+		{
+			name:     "separator with escaped newline",
+			tag:      `separator='\n'`,
+			expected: "\n",
+		},
+		{
+			name:     "separator with escaped tab and backslash",
+			tag:      `separator='\t\\'`,
+			expected: "\t\\",
+		},
+		{
+			name:     "csv separator defaults to comma",
+			tag:      "format='csv'",
+			expected: ",",
+		},
+		{
+			name:     "csv with separator",
+			tag:      "format='csv',separator=';'",
+			expected: ";",
 		},
 	}
 
@@ -308,6 +323,14 @@ func TestGetSeparatorPanic(t *testing.T) {
 			name: "multiple separators",
 			tag:  "separator=',',separator='|'",
 		},
+		{
+			name: "empty separator",
+			tag:  "separator=''",
+		},
+		{
+			name: "separator with unknown format",
+			tag:  "format='json'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -318,39 +341,38 @@ func TestGetSeparatorPanic(t *testing.T) {
 				}
 			}()
 			getSeparator(tt.tag)
+			getFormat(tt.tag)
 		})
 	}
 }
 
 func TestGetSeparatorInvalidFormat(t *testing.T) {
 	tests := []struct {
-		name     string
-		tag      string
-		expected string
+		name string
+		tag  string
 	}{
 		{
-			name:     "separator without quotes",
-			tag:      "separator=,",
-			expected: " ",
+			name: "separator without quotes",
+			tag:  "separator=,",
 		},
 		{
-			name:     "separator with double quotes",
-			tag:      `separator=","`,
-			expected: " ",
+			name: "separator with double quotes",
+			tag:  `separator=","`,
 		},
 		{
-			name:     "separator with invalid format",
-			tag:      "separator=invalid",
-			expected: " ",
+			name: "separator with invalid format",
+			tag:  "separator=invalid",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getSeparator(tt.tag)
-			if result != tt.expected {
-				t.Errorf("Expected '%s', got '%s' for tag '%s'", tt.expected, result, tt.tag)
-			}
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for tag '%s' but didn't get one", tt.tag)
+				}
+			}()
+			getSeparator(tt.tag)
 		})
 	}
 }
@@ -403,14 +425,19 @@ func TestGetName(t *testing.T) {
 			shouldPanic: true,
 		},
 		{
-			name:     "name without quotes",
-			tag:      "name=NO_QUOTES",
-			expected: "",
+			name:        "name without quotes",
+			tag:         "name=NO_QUOTES",
+			shouldPanic: true,
 		},
 		{
-			name:     "name with double quotes",
-			tag:      `name="DOUBLE_QUOTES"`,
-			expected: "",
+			name:        "name with double quotes",
+			tag:         `name="DOUBLE_QUOTES"`,
+			shouldPanic: true,
+		},
+		{
+			name:     "name with escaped quote",
+			tag:      `name='A\'B'`,
+			expected: "A'B",
 		},
 	}
 
@@ -468,16 +495,6 @@ func TestHasValues(t *testing.T) {
 			tag:      "",
 			expected: false,
 		},
-		{
-			name:     "values without quotes",
-			tag:      "values=8000,8080,9000",
-			expected: false,
-		},
-		{
-			name:     "values with double quotes",
-			tag:      `values="8000,8080,9000"`,
-			expected: false,
-		},
 		{
 			name:     "values with empty value",
 			tag:      "values=''",
@@ -593,33 +610,31 @@ func TestGetValuesPanic(t *testing.T) {
 
 func TestGetValuesInvalidFormat(t *testing.T) {
 	tests := []struct {
-		name     string
-		tag      string
-		expected []string
+		name string
+		tag  string
 	}{
 		{
-			name:     "values without quotes",
-			tag:      "values=8000,8080,9000",
-			expected: nil,
+			name: "values without quotes",
+			tag:  "values=8000,8080,9000",
 		},
 		{
-			name:     "values with double quotes",
-			tag:      `values="8000,8080,9000"`,
-			expected: nil,
+			name: "values with double quotes",
+			tag:  `values="8000,8080,9000"`,
 		},
 		{
-			name:     "values with invalid format",
-			tag:      "values=invalid",
-			expected: nil,
+			name: "values with invalid format",
+			tag:  "values=invalid",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := getValues(tt.tag)
-			if !equalStringSlices(result, tt.expected) {
-				t.Errorf("Expected %v, got %v for tag '%s'", tt.expected, result, tt.tag)
-			}
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("Expected panic for tag '%s' but didn't get one", tt.tag)
+				}
+			}()
+			getValues(tt.tag)
 		})
 	}
 }
@@ -0,0 +1,302 @@
+package env
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Values starting with a PEM header are read inline, anything else is the path
+// of a file. Inline values may have their newlines escaped as `\n`, as is
+// common when they are set in a single line.
+func readPEM(value string) ([]byte, error) {
+	if isInlinePEM(value) {
+		if !strings.Contains(value, "\n") {
+			value = strings.ReplaceAll(value, `\n`, "\n")
+		}
+		return []byte(value), nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %v", value, err)
+	}
+	return data, nil
+}
+
+func isInlinePEM(value string) bool {
+	return strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN")
+}
+
+// Reports whether values of the type, or the elements of a slice, are read
+// with readPEM
+func isPEMType(t reflect.Type) bool {
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t == reflect.TypeOf(Certificate{}) || t == reflect.TypeOf(PrivateKey{})
+}
+
+// Type: Certificate
+func certificateParser(value string) (Certificate, error) {
+	data, err := readPEM(value)
+	if err != nil {
+		return Certificate{}, err
+	}
+
+	var chain []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return Certificate{}, fmt.Errorf("invalid certificate %d: %v", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return Certificate{}, errors.New("no PEM certificate found")
+	}
+
+	return Certificate{chain}, nil
+}
+
+// Certificate is a PEM certificate, or a bundle of them, given inline or as
+// the path of a file. The first certificate is the leaf, followed by its
+// intermediates. Expired certificates are rejected, and so are the ones that
+// are not valid yet.
+type Certificate struct {
+	chain []*x509.Certificate
+}
+
+// Leaf returns the first certificate
+func (c Certificate) Leaf() *x509.Certificate {
+	if len(c.chain) == 0 {
+		return nil
+	}
+	return c.chain[0]
+}
+
+// Chain returns every certificate, starting with the leaf
+func (c Certificate) Chain() []*x509.Certificate {
+	return c.chain
+}
+
+// Pool returns the certificates as a pool, e.g. to trust a CA bundle
+func (c Certificate) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range c.chain {
+		pool.AddCert(cert)
+	}
+	return pool
+}
+
+// Checks that every certificate is valid at the given time, and that the leaf
+// is valid for the given names
+func checkCertificate(c Certificate, names []string, now time.Time) error {
+	for i, cert := range c.chain {
+		label := "certificate"
+		if len(c.chain) > 1 {
+			label = fmt.Sprintf("certificate %d", i)
+		}
+
+		switch {
+		case now.After(cert.NotAfter):
+			return fmt.Errorf("%s expired on %s", label, cert.NotAfter.UTC().Format(time.RFC3339))
+		case now.Before(cert.NotBefore):
+			return fmt.Errorf("%s is not valid before %s", label, cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+	}
+
+	for _, name := range names {
+		if err := c.Leaf().VerifyHostname(name); err != nil {
+			return fmt.Errorf("certificate is not valid for %s", name)
+		}
+	}
+	return nil
+}
+
+// Returns the names the certificate of the field must be valid for. The option
+// is only valid on Certificate fields, using it anywhere else is a programming
+// error.
+func certificateNamesFor(field reflect.StructField) []string {
+	names := getSANs(field.Tag.Get("env"))
+	if len(names) == 0 {
+		return nil
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t != reflect.TypeOf(Certificate{}) {
+		panic(fmt.Sprintf("Option 'san' of field '%s' is only valid for Certificate fields", field.Name))
+	}
+
+	return names
+}
+
+// Type: PrivateKey
+func privateKeyParser(value string) (PrivateKey, error) {
+	data, err := readPEM(value)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		var key any
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return PrivateKey{}, fmt.Errorf("invalid private key: %v", err)
+		}
+
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return PrivateKey{}, fmt.Errorf("unsupported private key %T", key)
+		}
+		return PrivateKey{signer}, nil
+	}
+
+	return PrivateKey{}, errors.New("no PEM private key found")
+}
+
+// PrivateKey is a PEM private key in PKCS #8, PKCS #1 or SEC 1 form, given
+// inline or as the path of a file. Fields holding keys should be tagged as
+// `secret`.
+type PrivateKey struct {
+	signer crypto.Signer
+}
+
+// Signer returns the key, e.g. an *ecdsa.PrivateKey
+func (k PrivateKey) Signer() crypto.Signer {
+	return k.signer
+}
+
+// Public returns the public key matching the private key
+func (k PrivateKey) Public() crypto.PublicKey {
+	if k.signer == nil {
+		return nil
+	}
+	return k.signer.Public()
+}
+
+// Reports whether the key matches the public key of the certificate
+func (k PrivateKey) matches(cert *x509.Certificate) bool {
+	public, ok := k.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && cert != nil && public.Equal(cert.PublicKey)
+}
+
+// Type: TLSVersion
+var tlsVersions = map[string]TLSVersion{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func tlsVersionParser(value string) (TLSVersion, error) {
+	version, ok := tlsVersions[strings.TrimPrefix(strings.ToLower(value), "tls")]
+	if !ok {
+		return 0, fmt.Errorf("invalid TLS version: %s", value)
+	}
+	return version, nil
+}
+
+// TLSVersion is a TLS version like `1.2` or `TLS1.3`, holding the value of the
+// matching crypto/tls constant, e.g. tls.VersionTLS12
+type TLSVersion uint16
+
+// TLS reads the TLS material of a service and builds a *tls.Config from it.
+// Used as a nested struct named TLS it reads `TLS_CERT`, `TLS_KEY`, `TLS_CA`
+// and `TLS_MIN_VERSION`:
+//
+//	type Config struct {
+//		TLS env.TLS
+//	}
+//
+// A key that doesn't match the certificate, or a minimum version below 1.2, is
+// reported as invalid when validating, see checkTLSKey and checkTLSVersion.
+type TLS struct {
+	Cert       Certificate `env:"required,desc='PEM certificate chain, inline or the path of a file'"`
+	Key        PrivateKey  `env:"required,secret,desc='PEM private key of the certificate, inline or the path of a file'"`
+	CA         Certificate `env:"optional,nostrict,desc='PEM bundle of the certificate authorities to trust'"`
+	MinVersion TLSVersion  `env:"optional,nostrict,name='MIN_VERSION',desc='Minimum TLS version, 1.2 or 1.3, 1.2 if not set'"`
+}
+
+// Checks that the key of a TLS struct in the scope matches its certificate,
+// once both are valid. It is part of validating rather than a Validate method
+// so that every validation reports it, including env.Validate.
+func checkTLSKey(s scope, environment envMapType) *FieldError {
+	t := reflect.TypeOf(TLS{})
+	certField, _ := t.FieldByName("Cert")
+	keyField, _ := t.FieldByName("Key")
+
+	cert, certOK := environment[s.key(certField)]
+	key, keyOK := environment[s.key(keyField)]
+	if !certOK || !keyOK || !cert.Value.IsValid() || !key.Value.IsValid() {
+		return nil
+	}
+	if key.Value.Interface().(PrivateKey).matches(cert.Value.Interface().(Certificate).Leaf()) {
+		return nil
+	}
+
+	e := s.fieldError(keyField, "", "the private key does not match the certificate")
+	return &e
+}
+
+// Checks that the minimum version of a TLS struct, as written in the
+// environment, is at least 1.2. It is checked once parsed so that every form
+// tlsVersionParser accepts is allowed, e.g. `TLS1.3`.
+func checkTLSVersion(s scope, value string) *FieldError {
+	version, err := tlsVersionParser(value)
+	if err != nil || version >= tls.VersionTLS12 {
+		return nil
+	}
+
+	field, _ := reflect.TypeOf(TLS{}).FieldByName("MinVersion")
+	e := s.fieldError(field, value, "TLS versions below 1.2 are not supported")
+	return &e
+}
+
+// Config returns a *tls.Config using the certificate and key. The CA bundle,
+// if any, is trusted both to verify servers and clients, although servers must
+// still set ClientAuth to request client certificates.
+func (t TLS) Config() *tls.Config {
+	config := &tls.Config{MinVersion: uint16(t.MinVersion)}
+	if config.MinVersion == 0 {
+		config.MinVersion = tls.VersionTLS12
+	}
+
+	if leaf := t.Cert.Leaf(); leaf != nil {
+		certificate := tls.Certificate{PrivateKey: t.Key.Signer(), Leaf: leaf}
+		for _, cert := range t.Cert.Chain() {
+			certificate.Certificate = append(certificate.Certificate, cert.Raw)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	if t.CA.Leaf() != nil {
+		config.RootCAs = t.CA.Pool()
+		config.ClientCAs = t.CA.Pool()
+	}
+
+	return config
+}
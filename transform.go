@@ -0,0 +1,106 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// The transforms applied to values before they are validated, set with tag
+// options like `lower`. They apply to the values of variables and to defaults,
+// and to each element of slices.
+type transforms struct {
+	trim     bool
+	unquote  bool
+	lower    bool
+	upper    bool
+	casefold bool
+}
+
+// Returns the names of the transforms, in the order in which they apply
+func (t transforms) names() []string {
+	var names []string
+	for _, transform := range []struct {
+		name    string
+		enabled bool
+	}{{"trim", t.trim}, {"unquote", t.unquote}, {"lower", t.lower}, {"upper", t.upper}, {"casefold", t.casefold}} {
+		if transform.enabled {
+			names = append(names, transform.name)
+		}
+	}
+	return names
+}
+
+// Returns the transforms of the field. Case folding compares the value with
+// the allowed values, so a field without any is a programming error.
+func transformsFor(field reflect.StructField) transforms {
+	t := getTransforms(field.Tag.Get("env"))
+	if t.casefold && allowedValues(field) == nil {
+		panic(fmt.Sprintf("Option 'casefold' of field '%s' needs values or an enum type", field.Name))
+	}
+	return t
+}
+
+// Applies the transforms to a single value. Values that match an allowed value
+// but for the case are replaced by it when case folding.
+func (t transforms) apply(value string, allowed []string) string {
+	if t.trim {
+		value = strings.TrimSpace(value)
+	}
+	if t.unquote {
+		value = unquote(value)
+	}
+	if t.lower {
+		value = strings.ToLower(value)
+	}
+	if t.upper {
+		value = strings.ToUpper(value)
+	}
+	if t.casefold {
+		for _, a := range allowed {
+			if strings.EqualFold(value, a) {
+				return a
+			}
+		}
+	}
+	return value
+}
+
+// Returns the value of the field after applying its transforms. Slices are
+// transformed element by element once split, only the quotes around the whole
+// list are removed here.
+func transformValue(field reflect.StructField, value string) string {
+	t := transformsFor(field)
+	if field.Type.Kind() == reflect.Slice {
+		if t.unquote {
+			return unquote(value)
+		}
+		return value
+	}
+	return t.apply(value, allowedValues(field))
+}
+
+// Applies the transforms of the field to the elements of a slice, in place
+func transformElements(field reflect.StructField, elements []string) {
+	t := transformsFor(field)
+	if t == (transforms{}) {
+		return
+	}
+
+	allowed := allowedValues(field)
+	for i, element := range elements {
+		elements[i] = t.apply(element, allowed)
+	}
+}
+
+// Removes a pair of matching quotes around the value, as left by tools that
+// pass `KEY="value"` through literally
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
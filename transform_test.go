@@ -0,0 +1,145 @@
+package env
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTransformsApply(t *testing.T) {
+	allowed := []string{"dev", "Staging", "PROD"}
+
+	tests := []struct {
+		name       string
+		transforms transforms
+		value      string
+		expected   string
+	}{
+		{name: "none", value: " Dev ", expected: " Dev "},
+		{name: "trim", transforms: transforms{trim: true}, value: " dev\t", expected: "dev"},
+		{name: "unquote double quotes", transforms: transforms{unquote: true}, value: `"dev"`, expected: "dev"},
+		{name: "unquote single quotes", transforms: transforms{unquote: true}, value: `'dev'`, expected: "dev"},
+		{name: "unquote unmatched quotes", transforms: transforms{unquote: true}, value: `"dev'`, expected: `"dev'`},
+		{name: "unquote a single quote", transforms: transforms{unquote: true}, value: `"`, expected: `"`},
+		{name: "trim before unquoting", transforms: transforms{trim: true, unquote: true}, value: ` "dev" `, expected: "dev"},
+		{name: "lower", transforms: transforms{lower: true}, value: "DEV", expected: "dev"},
+		{name: "upper", transforms: transforms{upper: true}, value: "prod", expected: "PROD"},
+		{name: "casefold to the allowed value", transforms: transforms{casefold: true}, value: "staging", expected: "Staging"},
+		{name: "casefold without a match", transforms: transforms{casefold: true}, value: "qa", expected: "qa"},
+		{name: "unquote and casefold", transforms: transforms{unquote: true, casefold: true}, value: `"prod"`, expected: "PROD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.transforms.apply(tt.value, allowed); result != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, result)
+			}
+		})
+	}
+}
+
+type TestConfigTransform struct {
+	Environment string            `env:"required,casefold,values='dev,staging,prod',name='TRENV'"`
+	Region      string            `env:"optional,upper,trim,name='TRREGION'"`
+	Token       string            `env:"optional,unquote,name='TRTOKEN'"`
+	Stage       testEnvironment   `env:"optional,lower,default='STAGING',name='TRSTAGE'"`
+	Modes       []string          `env:"optional,separator=',',lower,values='read,write',name='TRMODES'"`
+	Stages      []testEnvironment `env:"optional,separator=',',unquote,casefold,name='TRSTAGES'"`
+	Debug       bool              `env:"optional,lower,name='TRDEBUG'"`
+}
+
+func TestAssertTransforms(t *testing.T) {
+	t.Setenv("TRENV", "PROD")
+	t.Setenv("TRREGION", "  eu-west-1 ")
+	t.Setenv("TRTOKEN", `"abc def"`)
+	t.Setenv("TRMODES", "READ, Write")
+	t.Setenv("TRSTAGES", `"Dev,'PROD'"`)
+	t.Setenv("TRDEBUG", "TRUE")
+
+	config, err := Assert(TestConfigTransform{})
+	if err != nil {
+		t.Fatalf("Assert failed: %v", err)
+	}
+
+	expected := TestConfigTransform{
+		Environment: "prod",
+		Region:      "EU-WEST-1",
+		Token:       "abc def",
+		Stage:       testStaging,
+		Modes:       []string{"read", "write"},
+		Stages:      []testEnvironment{testDev, testProd},
+		Debug:       true,
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, config)
+	}
+}
+
+func TestValidateTransforms(t *testing.T) {
+	report := ValidateMap(TestConfigTransform{}, map[string]string{"TRENV": "Production", "TRMODES": "READ,delete"})
+
+	invalid := make(map[string]string)
+	for _, e := range report.Invalid {
+		invalid[e.label()] = e.Reason
+	}
+	expected := map[string]string{
+		"Environment (TRENV)": "",
+		"Modes (TRMODES)":     "element 1 is not allowed",
+	}
+	if !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected %v, got %v", expected, invalid)
+	}
+}
+
+func TestTransformsInSchema(t *testing.T) {
+	description := Describe(TestConfigTransform{})
+	if !reflect.DeepEqual(description[0].Transforms, []string{"casefold"}) ||
+		!reflect.DeepEqual(description[1].Transforms, []string{"trim", "upper"}) {
+		t.Errorf("Unexpected transforms: %v, %v", description[0].Transforms, description[1].Transforms)
+	}
+
+	// The schema can't tell whether a value matches once transformed
+	schema := NewSchema(TestConfigTransform{})
+	if property := schema.Properties["TRENV"]; len(property.Enum) != 0 || property.Pattern != "" {
+		t.Errorf("Expected no enum nor pattern, got %+v", property)
+	}
+	if report := schema.Check(map[string]string{"TRENV": "PROD"}); !report.Valid() {
+		t.Errorf("Expected the schema to accept a value accepted once transformed, got %+v", report)
+	}
+}
+
+func TestTransformPanics(t *testing.T) {
+	tests := []struct {
+		name   string
+		config any
+	}{
+		{
+			name: "casefold without values",
+			config: struct {
+				Name string `env:"required,casefold,name='TRNAME'"`
+			}{},
+		},
+		{
+			name: "default not allowed once transformed",
+			config: struct {
+				Mode string `env:"optional,upper,values='read,write',default='read',name='TRNAME'"`
+			}{},
+		},
+		{
+			name: "lower and upper",
+			config: struct {
+				Name string `env:"required,lower,upper,name='TRNAME'"`
+			}{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic")
+				}
+			}()
+			ValidateMap(tt.config, map[string]string{})
+		})
+	}
+}
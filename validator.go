@@ -0,0 +1,62 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Validator can be implemented by config structs, and by nested structs, to
+// perform checks that cannot be expressed with tags. For example, that a
+// minimum is not greater than a maximum. It is called by Assert once the struct
+// has been populated.
+type Validator interface {
+	Validate() error
+}
+
+// Calls the Validate method of the nested structs first and then the one of
+// the struct itself, returning the errors prefixed with the path of the struct
+// that produced them. The root struct is reported by its type name.
+func runValidators(value reflect.Value, path string) []string {
+	var failed []string
+
+	for n := 0; n < value.NumField(); n++ {
+		field := value.Type().Field(n)
+		if isNestedStruct(field.Type) && field.IsExported() {
+			nestedPath := field.Name
+			if path != "" {
+				nestedPath = path + "." + field.Name
+			}
+			failed = append(failed, runValidators(value.Field(n), nestedPath)...)
+		}
+	}
+
+	validator, ok := asValidator(value)
+	if !ok {
+		return failed
+	}
+
+	if err := validator.Validate(); err != nil {
+		name := path
+		if name == "" {
+			name = value.Type().Name()
+		}
+		if name == "" {
+			name = "config"
+		}
+		failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+	}
+
+	return failed
+}
+
+// The Validate method may be declared on the struct or on a pointer to it
+func asValidator(value reflect.Value) (Validator, bool) {
+	if value.CanAddr() {
+		if validator, ok := value.Addr().Interface().(Validator); ok {
+			return validator, true
+		}
+	}
+
+	validator, ok := value.Interface().(Validator)
+	return validator, ok
+}
@@ -0,0 +1,142 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Test structs for the Validate hook
+type TestPoolConfig struct {
+	MinConns int `env:"required"`
+	MaxConns int `env:"required"`
+}
+
+func (c TestPoolConfig) Validate() error {
+	if c.MinConns > c.MaxConns {
+		return errors.New("MinConns must be less than or equal to MaxConns")
+	}
+	return nil
+}
+
+type TestConfigWithValidator struct {
+	Pool    TestPoolConfig
+	Timeout int `env:"required"`
+}
+
+type TestConfigWithPointerValidator struct {
+	Start int `env:"required"`
+	End   int `env:"required"`
+}
+
+func (c *TestConfigWithPointerValidator) Validate() error {
+	if c.Start >= c.End {
+		return errors.New("Start must be before End")
+	}
+	return nil
+}
+
+func TestAssertValidator(t *testing.T) {
+	tests := []struct {
+		name          string
+		envVars       map[string]string
+		opts          []Option
+		expectError   bool
+		errorContains []string
+		notContains   []string
+	}{
+		{
+			name: "nested validator passes",
+			envVars: map[string]string{
+				"POOL_MINCONNS": "1",
+				"POOL_MAXCONNS": "10",
+				"TIMEOUT":       "30",
+			},
+			expectError: false,
+		},
+		{
+			name: "nested validator fails with path",
+			envVars: map[string]string{
+				"POOL_MINCONNS": "20",
+				"POOL_MAXCONNS": "10",
+				"TIMEOUT":       "30",
+			},
+			expectError:   true,
+			errorContains: []string{"Failed: [Pool: MinConns must be less than or equal to MaxConns]"},
+		},
+		{
+			name: "validator does not run when fields are invalid",
+			envVars: map[string]string{
+				"POOL_MINCONNS": "20",
+				"POOL_MAXCONNS": "10",
+				"TIMEOUT":       "not-a-number",
+			},
+			expectError:   true,
+			errorContains: []string{"Invalid:"},
+			notContains:   []string{"Failed:"},
+		},
+		{
+			name: "validator runs when fields are invalid with WithAlwaysValidate",
+			envVars: map[string]string{
+				"POOL_MINCONNS": "20",
+				"POOL_MAXCONNS": "10",
+				"TIMEOUT":       "not-a-number",
+			},
+			opts:          []Option{WithAlwaysValidate()},
+			expectError:   true,
+			errorContains: []string{"Invalid:", "Failed: [Pool: MinConns must be less than or equal to MaxConns]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.envVars {
+				os.Setenv(key, value)
+			}
+			defer func() {
+				for key := range tt.envVars {
+					os.Unsetenv(key)
+				}
+			}()
+
+			_, err := Assert(TestConfigWithValidator{}, tt.opts...)
+
+			if tt.expectError && err == nil {
+				t.Fatalf("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			for _, expected := range tt.errorContains {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("Expected error to contain '%s', got: %v", expected, err)
+				}
+			}
+			for _, unexpected := range tt.notContains {
+				if strings.Contains(err.Error(), unexpected) {
+					t.Errorf("Expected error not to contain '%s', got: %v", unexpected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestAssertPointerValidator(t *testing.T) {
+	os.Setenv("START", "10")
+	os.Setenv("END", "5")
+	defer func() {
+		os.Unsetenv("START")
+		os.Unsetenv("END")
+	}()
+
+	_, err := Assert(TestConfigWithPointerValidator{})
+	if err == nil {
+		t.Fatalf("Expected error but got none")
+	}
+
+	expected := "TestConfigWithPointerValidator: Start must be before End"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error to contain '%s', got: %v", expected, err)
+	}
+}
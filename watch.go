@@ -0,0 +1,144 @@
+package env
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+// WatchOptions configures Watch
+type WatchOptions struct {
+	// How often the files are checked for changes. Defaults to 5 seconds.
+	Interval time.Duration
+
+	// Additional files to watch. Dotenv files given with WithDotenv, the
+	// files read with WithFileSecrets and the files of certificates and keys
+	// are always watched.
+	Files []string
+
+	// Reloads the configuration whenever a value is received, regardless of
+	// whether any file changed.
+	Trigger <-chan struct{}
+
+	// Options passed to Assert on every reload
+	Options []Option
+}
+
+// The state of a watched file, compared between polls to detect changes
+type fileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+func statFile(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+	return fileState{true, info.ModTime(), info.Size()}
+}
+
+// Watch validates the configuration and validates it again every time one of
+// the files it was read from changes, or a reload is triggered. Only configs
+// that fully validate are sent, and only when they differ from the last one
+// sent, so the last good configuration stays in use when a reload fails. The
+// validation errors are sent on the error channel instead.
+//
+// Both channels must be drained, and are closed once the context is done.
+func Watch[T any](ctx context.Context, config T, opts WatchOptions) (<-chan T, <-chan error) {
+	configs := make(chan T)
+	errors := make(chan error)
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	go func() {
+		defer close(configs)
+		defer close(errors)
+
+		var last T
+		var published bool
+		watched := make(map[string]fileState)
+
+		reload := func() bool {
+			result, src, err := assert(config, newOptions(opts.Options))
+
+			// Snapshot the files before publishing so that changes made while
+			// the result is being consumed are not missed
+			watched = make(map[string]fileState)
+			for _, path := range opts.Files {
+				watched[path] = statFile(path)
+			}
+			if src != nil {
+				for _, path := range src.files {
+					watched[path] = statFile(path)
+				}
+			}
+			for _, path := range newOptions(opts.Options).dotenv {
+				watched[path] = statFile(path)
+			}
+
+			if err != nil {
+				select {
+				case errors <- err:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			if published && reflect.DeepEqual(result, last) {
+				return true
+			}
+
+			select {
+			case configs <- result:
+				last, published = result, true
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if !reload() {
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-opts.Trigger:
+				if !reload() {
+					return
+				}
+			case <-ticker.C:
+				if changed(watched) && !reload() {
+					return
+				}
+			}
+		}
+	}()
+
+	return configs, errors
+}
+
+// Checks whether any of the watched files changed since they were last seen
+func changed(watched map[string]fileState) bool {
+	for path, state := range watched {
+		current := statFile(path)
+		if current.exists != state.exists || current.size != state.size || !current.modTime.Equal(state.modTime) {
+			return true
+		}
+	}
+	return false
+}
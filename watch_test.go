@@ -0,0 +1,96 @@
+package env
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type TestWatchConfig struct {
+	Workers int  `env:"name='WATCH_WORKERS',required"`
+	Debug   bool `env:"name='WATCH_DEBUG',optional,default='false'"`
+}
+
+// Writes the file and moves its modification time forward, so that the change
+// is detected even when it happens within the resolution of the filesystem
+func writeWatchedFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write '%s': %v", path, err)
+	}
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(path, future, future)
+}
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case value := <-ch:
+		return value
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Timed out waiting for value")
+	}
+	var zero T
+	return zero
+}
+
+func TestWatchDotenv(t *testing.T) {
+	dotenv := filepath.Join(t.TempDir(), ".env")
+	writeWatchedFile(t, dotenv, "WATCH_WORKERS=4\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	configs, errors := Watch(ctx, TestWatchConfig{}, WatchOptions{
+		Interval: 10 * time.Millisecond,
+		Options:  []Option{WithDotenv(dotenv)},
+	})
+
+	if config := receive(t, configs); config.Workers != 4 {
+		t.Errorf("Expected 4 workers, got %d", config.Workers)
+	}
+
+	// An invalid change is reported and no config is sent
+	writeWatchedFile(t, dotenv, "WATCH_WORKERS=many\n")
+	if err := receive(t, errors); err == nil {
+		t.Errorf("Expected validation error")
+	}
+
+	writeWatchedFile(t, dotenv, "WATCH_WORKERS=8\nWATCH_DEBUG=true\n")
+	config := receive(t, configs)
+	if config.Workers != 8 || !config.Debug {
+		t.Errorf("Expected 8 workers with debug, got %+v", config)
+	}
+
+	cancel()
+	if _, ok := <-configs; ok {
+		t.Errorf("Expected configs channel to be closed")
+	}
+}
+
+func TestWatchTrigger(t *testing.T) {
+	os.Setenv("WATCH_WORKERS", "2")
+	defer os.Unsetenv("WATCH_WORKERS")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	trigger := make(chan struct{})
+	configs, _ := Watch(ctx, TestWatchConfig{}, WatchOptions{
+		Interval: time.Hour,
+		Trigger:  trigger,
+	})
+
+	if config := receive(t, configs); config.Workers != 2 {
+		t.Errorf("Expected 2 workers, got %d", config.Workers)
+	}
+
+	os.Setenv("WATCH_WORKERS", "3")
+	trigger <- struct{}{}
+
+	if config := receive(t, configs); config.Workers != 3 {
+		t.Errorf("Expected 3 workers, got %d", config.Workers)
+	}
+}